@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ApiException API异常类
@@ -9,6 +10,7 @@ type ApiException struct {
 	ErrMsg  string
 	ErrCode string
 	Cause   error
+	Body    string // 网关返回的原始响应体（如有）
 }
 
 // Error 实现error接口
@@ -19,6 +21,11 @@ func (e *ApiException) Error() string {
 	return fmt.Sprintf("API错误 - 错误码: %s, 错误信息: %s", e.ErrCode, e.ErrMsg)
 }
 
+// Unwrap 返回底层原因，便于 errors.Is/errors.As 判断
+func (e *ApiException) Unwrap() error {
+	return e.Cause
+}
+
 // NewApiException 创建一个新的API异常
 func NewApiException(errMsg string, errCode string, cause error) *ApiException {
 	return &ApiException{
@@ -28,19 +35,53 @@ func NewApiException(errMsg string, errCode string, cause error) *ApiException {
 	}
 }
 
+// RuleViolation 字段级校验错误
+type RuleViolation struct {
+	Field   string
+	Message string
+}
+
 // ApiRuleException API规则异常类
 type ApiRuleException struct {
-	ErrMsg  string
-	ErrCode string
-	Cause   error
+	ErrMsg     string
+	ErrCode    string
+	Cause      error
+	Violations []RuleViolation
 }
 
 // Error 实现error接口
 func (e *ApiRuleException) Error() string {
+	msg := fmt.Sprintf("API规则错误 - 错误码: %s, 错误信息: %s", e.ErrCode, e.ErrMsg)
+	if len(e.Violations) > 0 {
+		details := make([]string, 0, len(e.Violations))
+		for _, v := range e.Violations {
+			details = append(details, fmt.Sprintf("%s: %s", v.Field, v.Message))
+		}
+		msg += fmt.Sprintf(", 字段错误: [%s]", strings.Join(details, "; "))
+	}
 	if e.Cause != nil {
-		return fmt.Sprintf("API规则错误 - 错误码: %s, 错误信息: %s, 原因: %v", e.ErrCode, e.ErrMsg, e.Cause)
+		msg += fmt.Sprintf(", 原因: %v", e.Cause)
 	}
-	return fmt.Sprintf("API规则错误 - 错误码: %s, 错误信息: %s", e.ErrCode, e.ErrMsg)
+	return msg
+}
+
+// AddViolation 添加一条字段级校验错误
+func (e *ApiRuleException) AddViolation(field, message string) *ApiRuleException {
+	e.Violations = append(e.Violations, RuleViolation{Field: field, Message: message})
+	return e
+}
+
+// ErrOrNil 存在字段级校验错误时返回自身，否则返回nil
+func (e *ApiRuleException) ErrOrNil() error {
+	if len(e.Violations) == 0 && e.Cause == nil {
+		return nil
+	}
+	return e
+}
+
+// Unwrap 返回底层原因，便于 errors.Is/errors.As 判断
+func (e *ApiRuleException) Unwrap() error {
+	return e.Cause
 }
 
 // NewApiRuleException 创建一个新的API规则异常
@@ -0,0 +1,6 @@
+package api
+
+import "github.com/zhoudm1743/unicom-gw/api/internal/charset"
+
+// UnsupportedCharsetError 不支持的字符集，可通过 errors.As 判断
+type UnsupportedCharsetError = charset.UnsupportedCharsetError
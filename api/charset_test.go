@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zhoudm1743/unicom-gw/api/sign"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestClientGBKRoundTrip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != "application/json;charset=GBK" {
+			t.Errorf("Content-Type = %q, want application/json;charset=GBK", got)
+		}
+
+		raw, _ := ioutil.ReadAll(r.Body)
+		body, err := simplifiedchinese.GBK.NewDecoder().Bytes(raw)
+		if err != nil {
+			t.Errorf("请求报文不是合法的GBK: %v", err)
+		}
+		var params sentParams
+		if err := json.Unmarshal(body, &params); err != nil {
+			t.Fatalf("无法解析请求报文: %v", err)
+		}
+		if params.Data["remark"] != "中文备注" {
+			t.Errorf("data.remark = %v, want 中文备注", params.Data["remark"])
+		}
+
+		token, err := sign.SM3TokenSigner{Charset: CHARSET_GBK}.Sign(map[string]interface{}{
+			sign.KeyAppID:     params.AppID,
+			sign.KeyTimestamp: params.Timestamp,
+			sign.KeyTransID:   params.TransID,
+		}, "密钥")
+		if err != nil || params.Token != token {
+			t.Errorf("token = %q, want GBK摘要 %q (%v)", params.Token, token, err)
+		}
+
+		resp, _ := simplifiedchinese.GBK.NewEncoder().Bytes([]byte(`{"status":"0000","message":"成功","data":{}}`))
+		w.Header().Set("Content-Type", "application/json;charset=GBK")
+		w.Write(resp)
+	}))
+	defer srv.Close()
+
+	client := NewIoTGatewayClient(srv.URL, "app", "密钥", "")
+	if err := client.SetCharset("gbk"); err != nil {
+		t.Fatalf("SetCharset() error = %v", err)
+	}
+	req := newTestRequest()
+	req.params["remark"] = "中文备注"
+
+	resp, err := client.Execute(req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(resp.GetBody(), "成功") {
+		t.Errorf("响应未解码为UTF-8: %q", resp.GetBody())
+	}
+}
+
+func TestClientRejectsUnsupportedCharset(t *testing.T) {
+	client := NewIoTGatewayClient("http://127.0.0.1", "app", "secret", "")
+	err := client.SetCharset("latin1")
+
+	var csErr *UnsupportedCharsetError
+	if !errors.As(err, &csErr) {
+		t.Errorf("SetCharset(latin1) error = %v, want UnsupportedCharsetError", err)
+	}
+	if got := client.GetCharset(); got != CHARSET_UTF8 {
+		t.Errorf("GetCharset() = %q, 设置失败后应保持 %s", got, CHARSET_UTF8)
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultClockSkewTolerance 从 Date 头学习时钟偏差的默认容差，Date 头只精确到秒，偏差在容差内时视为无偏差
+const DefaultClockSkewTolerance = 2 * time.Second
+
+// Clock 时钟，客户端通过它获取构建签名令牌使用的当前时间
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc 函数形式的时钟
+type ClockFunc func() time.Time
+
+// Now 获取当前时间
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// systemClock 系统时钟
+type systemClock struct{}
+
+// Now 获取系统当前时间
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock 获取系统时钟
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// clockSkew 网关时钟偏差补偿，记录网关时间与本地时钟的差值
+type clockSkew struct {
+	mu             sync.Mutex
+	enabled        bool
+	tolerance      time.Duration
+	offset         time.Duration
+	rejectionCodes map[string]bool
+}
+
+// newClockSkew 创建未启用的时钟偏差补偿
+func newClockSkew() *clockSkew {
+	return &clockSkew{tolerance: DefaultClockSkewTolerance}
+}
+
+// isEnabled 是否启用补偿
+func (s *clockSkew) isEnabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enabled
+}
+
+// apply 启用补偿时在本地时间上叠加已学习的偏差
+func (s *clockSkew) apply(now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.enabled {
+		return now
+	}
+	return now.Add(s.offset)
+}
+
+// observe 根据响应的 Date 头学习偏差
+//
+// sent、received 为本地时钟下的请求发出与响应到达时间。Date 头只精确到秒，按该秒的中点估算网关时间；
+// 偏差不超过容差时视为无偏差，网关以时间戳错误拒绝请求时无论大小都采用估算值。
+// 没有 Date 头或无法解析时无从估算，保留已学习的偏差。
+func (s *clockSkew) observe(sent, received time.Time, header http.Header, rejected bool) {
+	date, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		return
+	}
+
+	local := sent.Add(received.Sub(sent) / 2)
+	offset := date.Add(500 * time.Millisecond).Sub(local)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !rejected && offset < s.tolerance && offset > -s.tolerance {
+		offset = 0
+	}
+	s.offset = offset
+}
+
+// isRejection 网关状态码是否表示时间戳被拒绝
+func (s *clockSkew) isRejection(status string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rejectionCodes[status]
+}
+
+// hasRejectionCodes 是否配置了时间戳拒绝状态码
+func (s *clockSkew) hasRejectionCodes() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.rejectionCodes) > 0
+}
+
+// interceptor 观察每次尝试的响应并学习偏差，位于拦截器链最内层
+func (s *clockSkew) interceptor(clock Clock) Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*CallResult, error) {
+			sent := clock.Now()
+			result, err := next(ctx, call)
+			received := clock.Now()
+
+			var header http.Header
+			var body string
+			if result != nil {
+				header, body = result.Header, result.Body
+			}
+			var httpErr *HTTPError
+			if errors.As(err, &httpErr) {
+				header, body = httpErr.Header, httpErr.Body
+			}
+			if header == nil {
+				return result, err
+			}
+
+			rejected := false
+			if body != "" && s.hasRejectionCodes() {
+				if env, decodeErr := DecodeEnvelope(body); decodeErr == nil {
+					rejected = s.isRejection(env.Status)
+				}
+			}
+			s.observe(sent, received, header, rejected)
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClockSkewObserve(t *testing.T) {
+	sent := time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)
+	received := sent.Add(200 * time.Millisecond)
+	dateHeader := func(d time.Duration) http.Header {
+		return http.Header{"Date": []string{sent.Add(d).Format(http.TimeFormat)}}
+	}
+
+	tests := []struct {
+		name     string
+		header   http.Header
+		rejected bool
+		initial  time.Duration
+		want     time.Duration
+	}{
+		{"容差内视为无偏差", dateHeader(time.Second), false, 0, 0},
+		{"超出容差采用估算值", dateHeader(time.Minute), false, 0, time.Minute + 400*time.Millisecond},
+		{"网关较慢", dateHeader(-time.Minute), false, 0, -time.Minute + 400*time.Millisecond},
+		{"时间戳被拒绝时无视容差", dateHeader(time.Second), true, 0, time.Second + 400*time.Millisecond},
+		{"没有Date头保留原偏差", http.Header{}, true, time.Hour, time.Hour},
+		{"Date头无法解析保留原偏差", http.Header{"Date": []string{"yesterday"}}, false, time.Hour, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skew := newClockSkew()
+			skew.enabled = true
+			skew.offset = tt.initial
+
+			skew.observe(sent, received, tt.header, tt.rejected)
+			if skew.offset != tt.want {
+				t.Errorf("offset = %s, want %s", skew.offset, tt.want)
+			}
+			if got := skew.apply(sent); !got.Equal(sent.Add(tt.want)) {
+				t.Errorf("apply() = %s, want %s", got, sent.Add(tt.want))
+			}
+		})
+	}
+}
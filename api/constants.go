@@ -12,22 +12,44 @@ const (
 
 	// 日期时间格式
 	DATE_TIME_FORMAT = "2006-01-02 15:04:05"
+	DATE_FORMAT      = "2006-01-02"
 	DATE_TIMEZONE    = "GMT+8"
 
 	// 字符集
-	CHARSET_UTF8 = "UTF-8"
-	CHARSET_GBK  = "GBK"
+	CHARSET_UTF8    = "UTF-8"
+	CHARSET_GBK     = "GBK"
+	CHARSET_GB18030 = "GB18030"
 
-	// 签名方法
-	SIGN_METHOD_MD5  = "md5"
-	SIGN_METHOD_HMAC = "hmac"
+	// 签名方法，与 sign 包的 Method* 一致
+	SIGN_METHOD_SM3      = "sm3"
+	SIGN_METHOD_MD5      = "md5"
+	SIGN_METHOD_HMAC     = "hmac"
+	SIGN_METHOD_HMAC_SM3 = "hmac-sm3"
 
 	// SDK版本
 	SDK_VERSION = "iot-gateway-sdk-go-20240101"
 
 	// 响应编码
-	ERROR_CODE = "status"
-	ERROR_MSG  = "message"
+	ERROR_CODE        = "status"
+	ERROR_MSG         = "message"
+	RESPONSE_TRANS_ID = "trans_id"
+	RESPONSE_DATA     = "data"
+
+	// 客户端自动注入的业务数据字段
+	DATA_OPEN_ID    = "openId"
+	DATA_MESSAGE_ID = "messageId"
+	DATA_VERSION    = "version"
+
+	// 网关成功状态码
+	STATUS_SUCCESS      = "0000"
+	STATUS_SUCCESS_ZERO = "0"
+
+	// 客户端错误码
+	ERR_CODE_CANCELED = "REQUEST_CANCELED"
+	ERR_CODE_TIMEOUT  = "REQUEST_TIMEOUT"
+	ERR_CODE_INVALID  = "INVALID_REQUEST"
+	ERR_CODE_DECODE   = "RESPONSE_DECODE_ERROR"
+	ERR_CODE_CHARSET  = "CHARSET_ERROR"
 
 	// HTTP头
 	ACCEPT_ENCODING       = "Accept-Encoding"
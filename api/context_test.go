@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingServer 请求一直阻塞到测试结束，并统计收到的请求数
+func blockingServer(calls *int32) (*httptest.Server, func()) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	return srv, func() {
+		close(release)
+		srv.Close()
+	}
+}
+
+func TestExecuteContextAbortsInFlightRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		target  error
+		errCode string
+	}{
+		{
+			name: "取消",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(100*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			target:  context.Canceled,
+			errCode: ERR_CODE_CANCELED,
+		},
+		{
+			name: "截止时间到期",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 100*time.Millisecond)
+			},
+			target:  context.DeadlineExceeded,
+			errCode: ERR_CODE_TIMEOUT,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv, stop := blockingServer(&calls)
+			defer stop()
+
+			client := NewIoTGatewayClient(srv.URL, "app", "secret", "")
+			client.SetRetryCount(3)
+			req := newTestRequest()
+			req.idempotent = true
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			start := time.Now()
+			resp, err := client.ExecuteContext(ctx, req)
+			elapsed := time.Since(start)
+
+			if resp != nil {
+				t.Errorf("ExecuteContext() resp = %v, want nil", resp)
+			}
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("ExecuteContext() error = %v, want errors.Is %v", err, tt.target)
+			}
+			var apiErr *ApiException
+			if !errors.As(err, &apiErr) || apiErr.ErrCode != tt.errCode {
+				t.Errorf("ExecuteContext() error = %v, want ApiException %s", err, tt.errCode)
+			}
+			if elapsed > time.Second {
+				t.Errorf("请求未及时中止，耗时 %s", elapsed)
+			}
+			if got := atomic.LoadInt32(&calls); got != 1 {
+				t.Errorf("上下文结束后不应继续重试，网关收到 %d 次请求", got)
+			}
+		})
+	}
+}
+
+func TestExecuteContextAlreadyCancelled(t *testing.T) {
+	var calls int32
+	srv, stop := blockingServer(&calls)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewIoTGatewayClient(srv.URL, "app", "secret", "").ExecuteContext(ctx, newTestRequest())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ExecuteContext() error = %v, want context.Canceled", err)
+	}
+	if calls != 0 {
+		t.Errorf("已取消的上下文不应发出请求，网关收到 %d 次请求", calls)
+	}
+}
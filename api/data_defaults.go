@@ -0,0 +1,58 @@
+package api
+
+import (
+	"github.com/zhoudm1743/unicom-gw/api/internal/utils"
+)
+
+// DefaultDataOptOut 可选接口，请求实现后可以关闭客户端向业务数据注入的默认字段
+type DefaultDataOptOut interface {
+	// IsDefaultDataDisabled 返回true时客户端不注入 openId、messageId、version
+	IsDefaultDataDisabled() bool
+}
+
+// IdempotentRequest 可选接口，请求实现后声明可以安全地重复发送
+//
+// 未实现或返回false的请求视为非幂等，默认重试策略只重试请求发出前的失败。
+type IdempotentRequest interface {
+	// IsIdempotent 返回true时重复执行不会产生额外副作用，如查询类接口
+	IsIdempotent() bool
+}
+
+// isIdempotent 判断请求是否声明为幂等
+func isIdempotent(request IoTGatewayRequest) bool {
+	idempotent, ok := request.(IdempotentRequest)
+	return ok && idempotent.IsIdempotent()
+}
+
+// applyDataDefaults 以请求自身的业务参数为准，补充 openId、messageId、version 默认值
+//
+// openId 取自客户端，messageId 默认使用本次交易ID，version 取自请求的 ApiVer。
+// 返回新的参数表，不修改请求持有的参数。
+func applyDataDefaults(request IoTGatewayRequest, data map[string]interface{}, openID, transID string) map[string]interface{} {
+	if optOut, ok := request.(DefaultDataOptOut); ok && optOut.IsDefaultDataDisabled() {
+		return data
+	}
+
+	defaults := map[string]string{
+		DATA_OPEN_ID:    openID,
+		DATA_MESSAGE_ID: transID,
+		DATA_VERSION:    request.GetApiVer(),
+	}
+
+	merged := make(map[string]interface{}, len(data)+len(defaults))
+	for key, value := range defaults {
+		if value != "" {
+			merged[key] = value
+		}
+	}
+	for key, value := range data {
+		merged[key] = value
+	}
+	return merged
+}
+
+// transIDOf 获取 BuildAppParams 生成的交易ID
+func transIDOf(params map[string]interface{}) string {
+	transID, _ := params[utils.TransIDKey].(string)
+	return transID
+}
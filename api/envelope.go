@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Envelope 网关响应的顶层信封
+type Envelope struct {
+	// Status 网关状态码
+	Status string
+	// Message 网关消息
+	Message string
+	// TransId 交易ID
+	TransId string
+	// Data 业务数据的原始JSON
+	Data json.RawMessage
+	// Extra 信封中的其他顶层字段
+	Extra map[string]json.RawMessage
+}
+
+// HasStatus 响应中是否带有状态码
+func (e *Envelope) HasStatus() bool {
+	return e.Status != ""
+}
+
+// IsSuccess 状态码是否表示成功
+func (e *Envelope) IsSuccess() bool {
+	return IsSuccessStatus(e.Status)
+}
+
+// IsSuccessStatus 判断网关状态码是否表示成功
+func IsSuccessStatus(status string) bool {
+	return status == STATUS_SUCCESS || status == STATUS_SUCCESS_ZERO
+}
+
+// DecodeEnvelope 解析网关响应的顶层信封，状态码既可以是字符串也可以是数字
+func DecodeEnvelope(body string) (*Envelope, error) {
+	var fields map[string]json.RawMessage
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+
+	env := &Envelope{
+		Extra: make(map[string]json.RawMessage),
+	}
+	for key, value := range fields {
+		switch key {
+		case ERROR_CODE:
+			env.Status = rawToString(value)
+		case ERROR_MSG:
+			env.Message = rawToString(value)
+		case RESPONSE_TRANS_ID:
+			env.TransId = rawToString(value)
+		case RESPONSE_DATA:
+			env.Data = value
+		default:
+			env.Extra[key] = value
+		}
+	}
+	return env, nil
+}
+
+// DecodeData 将 data 的原始JSON解码到 out，数字按 json.Number 保留精度
+//
+// out 可以是任意结构体、切片或标量的指针；为 *json.RawMessage 时直接复制原始JSON。
+// data 缺失或为 null 时不修改 out。
+func DecodeData(data json.RawMessage, out interface{}) error {
+	if out == nil {
+		return nil
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if raw, ok := out.(*json.RawMessage); ok {
+		*raw = append((*raw)[:0], trimmed...)
+		return nil
+	}
+	if len(trimmed) == 0 || string(trimmed) == "null" {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	return decoder.Decode(out)
+}
+
+// rawToString 将JSON字符串、数字等标量转换为字符串，null 返回空串
+func rawToString(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+	}
+	return string(raw)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecodeEnvelope(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantErr     bool
+		wantStatus  string
+		wantMessage string
+		wantTransID string
+		wantData    string
+		wantSuccess bool
+		wantExtra   []string
+	}{
+		{
+			name:        "字符串状态码",
+			body:        `{"status":"0000","message":"成功","trans_id":"20240101120000000000001","data":{"iccid":"89860625680009634556"}}`,
+			wantStatus:  "0000",
+			wantMessage: "成功",
+			wantTransID: "20240101120000000000001",
+			wantData:    `{"iccid":"89860625680009634556"}`,
+			wantSuccess: true,
+		},
+		{
+			name:        "数字状态码",
+			body:        `{"status":0,"message":"ok"}`,
+			wantStatus:  "0",
+			wantMessage: "ok",
+			wantSuccess: true,
+		},
+		{
+			name:        "失败状态码",
+			body:        `{"status":"1001","message":"参数错误","data":null}`,
+			wantStatus:  "1001",
+			wantMessage: "参数错误",
+			wantData:    "null",
+		},
+		{
+			name:        "数组与标量data保留原始JSON",
+			body:        `{"status":"0000","data":[1,2,12345678901234567890]}`,
+			wantStatus:  "0000",
+			wantData:    `[1,2,12345678901234567890]`,
+			wantSuccess: true,
+		},
+		{
+			name:        "其他顶层字段进入Extra",
+			body:        `{"status":"0000","requestId":"abc","cost":12}`,
+			wantStatus:  "0000",
+			wantSuccess: true,
+			wantExtra:   []string{"cost", "requestId"},
+		},
+		{
+			name:    "空响应",
+			body:    ``,
+			wantErr: true,
+		},
+		{
+			name:    "格式错误",
+			body:    `{"status":"0000"`,
+			wantErr: true,
+		},
+		{
+			name:    "HTML错误页",
+			body:    `<html>502 Bad Gateway</html>`,
+			wantErr: true,
+		},
+		{
+			name:    "顶层为数组",
+			body:    `[{"status":"0000"}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := DecodeEnvelope(tt.body)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DecodeEnvelope(%q) 期望返回错误", tt.body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeEnvelope() error = %v", err)
+			}
+
+			if env.Status != tt.wantStatus || env.Message != tt.wantMessage || env.TransId != tt.wantTransID {
+				t.Errorf("信封 = %+v", env)
+			}
+			if string(env.Data) != tt.wantData {
+				t.Errorf("Data = %s, want %s", env.Data, tt.wantData)
+			}
+			if env.IsSuccess() != tt.wantSuccess {
+				t.Errorf("IsSuccess() = %v, want %v", env.IsSuccess(), tt.wantSuccess)
+			}
+			if len(env.Extra) != len(tt.wantExtra) {
+				t.Errorf("Extra = %v, want keys %v", env.Extra, tt.wantExtra)
+			}
+			for _, key := range tt.wantExtra {
+				if _, ok := env.Extra[key]; !ok {
+					t.Errorf("Extra 缺少 %s", key)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeData(t *testing.T) {
+	var obj struct {
+		Iccid string      `json:"iccid"`
+		Usage json.Number `json:"usage"`
+	}
+	if err := DecodeData(json.RawMessage(`{"iccid":"89860625680009634556","usage":12345678901234567890}`), &obj); err != nil {
+		t.Fatalf("DecodeData() error = %v", err)
+	}
+	if obj.Usage.String() != "12345678901234567890" {
+		t.Errorf("数字精度丢失: %s", obj.Usage)
+	}
+
+	var generic interface{}
+	if err := DecodeData(json.RawMessage(`[1, 9007199254740993]`), &generic); err != nil {
+		t.Fatalf("DecodeData() error = %v", err)
+	}
+	if n := generic.([]interface{})[1].(json.Number); n.String() != "9007199254740993" {
+		t.Errorf("数字精度丢失: %s", n)
+	}
+
+	var scalar string
+	if err := DecodeData(json.RawMessage(`"ok"`), &scalar); err != nil || scalar != "ok" {
+		t.Errorf("DecodeData() 标量 = %q, %v", scalar, err)
+	}
+
+	var raw json.RawMessage
+	if err := DecodeData(json.RawMessage(` {"a":1} `), &raw); err != nil || string(raw) != `{"a":1}` {
+		t.Errorf("DecodeData() RawMessage = %s, %v", raw, err)
+	}
+
+	untouched := "keep"
+	if err := DecodeData(json.RawMessage(`null`), &untouched); err != nil || untouched != "keep" {
+		t.Errorf("data 为 null 时不应修改 out: %q, %v", untouched, err)
+	}
+}
+
+func TestExecuteReturnsDecodeErrorWithBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"空响应", ""},
+		{"格式错误", "<html>bad gateway</html>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			client := NewIoTGatewayClient(srv.URL, "app", "secret", "open")
+			resp, err := client.Execute(newTestRequest())
+			if resp != nil {
+				t.Errorf("解码失败时不应返回响应: %+v", resp)
+			}
+
+			var apiErr *ApiException
+			if !errors.As(err, &apiErr) || apiErr.ErrCode != ERR_CODE_DECODE {
+				t.Fatalf("Execute() error = %v, want ERR_CODE_DECODE", err)
+			}
+			if apiErr.Body != tt.body {
+				t.Errorf("Body = %q, want %q", apiErr.Body, tt.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// gatewayLocation 网关使用的东八区时区（DATE_TIMEZONE）
+var gatewayLocation = time.FixedZone(DATE_TIMEZONE, 8*3600)
+
+// gatewayTimeLayouts 网关可能返回的时间格式，不带时区的按东八区解析
+var gatewayTimeLayouts = []string{
+	DATE_TIME_FORMAT,
+	"2006-01-02 15:04:05.000",
+	"2006-01-02 15:04:05.000-0700",
+	"2006-01-02 15:04:05-0700",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"20060102150405",
+	DATE_FORMAT,
+	"20060102",
+}
+
+// GatewayLocation 获取网关使用的东八区时区
+func GatewayLocation() *time.Location {
+	return gatewayLocation
+}
+
+// ParseGatewayTime 解析网关返回的时间，不带时区信息的时间按东八区处理
+func ParseGatewayTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range gatewayTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, gatewayLocation); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无法解析网关时间: %s", s)
+}
+
+// FormatGatewayTime 按网关的日期时间格式在东八区格式化时间
+func FormatGatewayTime(t time.Time) string {
+	return t.In(gatewayLocation).Format(DATE_TIME_FORMAT)
+}
+
+// FormatGatewayDate 按网关的日期格式在东八区格式化日期
+func FormatGatewayDate(t time.Time) string {
+	return t.In(gatewayLocation).Format(DATE_FORMAT)
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport 统计经过的请求数并转交给下层传输层
+type countingTransport struct {
+	next  http.RoundTripper
+	count int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.count, 1)
+	return t.next.RoundTrip(req)
+}
+
+func okServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"0000","message":"成功","data":{}}`))
+	}))
+}
+
+func TestSharedClientReusesConnections(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"0000","message":"成功","data":{}}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	client := NewIoTGatewayClient(srv.URL, "app", "secret", "")
+	first, err := client.getHTTPClient()
+	if err != nil {
+		t.Fatalf("getHTTPClient() error = %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := client.Execute(newTestRequest()); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+
+	second, _ := client.getHTTPClient()
+	if first != second {
+		t.Error("多次请求应共享同一个HTTP客户端")
+	}
+	if got := atomic.LoadInt32(&conns); got != 1 {
+		t.Errorf("建立了 %d 个连接, want 1", got)
+	}
+}
+
+func TestPoolSettingsTakeEffect(t *testing.T) {
+	client := NewIoTGatewayClient("http://127.0.0.1", "app", "secret", "")
+	before, _ := client.getHTTPClient()
+
+	client.SetMaxIdleConnsPerHost(7)
+	client.SetIdleConnTimeout(1500)
+	client.SetHTTP2Enabled(false)
+	client.SetMinTLSVersion(tls.VersionTLS13)
+
+	after, err := client.getHTTPClient()
+	if err != nil {
+		t.Fatalf("getHTTPClient() error = %v", err)
+	}
+	if before == after {
+		t.Fatal("修改连接池配置后应重建共享客户端")
+	}
+
+	transport, ok := after.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport 类型 = %T, want *http.Transport", after.Transport)
+	}
+	if transport.MaxIdleConnsPerHost != 7 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 7", transport.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 1500*time.Millisecond {
+		t.Errorf("IdleConnTimeout = %s, want 1.5s", transport.IdleConnTimeout)
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil || len(transport.TLSNextProto) != 0 {
+		t.Error("禁用HTTP/2后不应协商h2")
+	}
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Error("TLS最低版本未生效")
+	}
+}
+
+func TestCallerHTTPClientIsUsed(t *testing.T) {
+	srv := okServer()
+	defer srv.Close()
+
+	rt := &countingTransport{next: http.DefaultTransport}
+	client := NewIoTGatewayClient(srv.URL, "app", "secret", "")
+	client.SetHTTPClient(&http.Client{Transport: rt})
+	if _, err := client.Execute(newTestRequest()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if rt.count != 1 {
+		t.Errorf("调用方客户端处理了 %d 次请求, want 1", rt.count)
+	}
+	if got, _ := client.getHTTPClient(); got != client.GetHTTPClient() {
+		t.Error("应使用调用方提供的HTTP客户端")
+	}
+}
+
+func TestCallerTransportIsUsed(t *testing.T) {
+	srv := okServer()
+	defer srv.Close()
+
+	rt := &countingTransport{next: http.DefaultTransport}
+	client := NewIoTGatewayClient(srv.URL, "app", "secret", "")
+	client.SetTransport(rt)
+	for i := 0; i < 2; i++ {
+		if _, err := client.Execute(newTestRequest()); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+	if rt.count != 2 {
+		t.Errorf("调用方传输层处理了 %d 次请求, want 2", rt.count)
+	}
+}
+
+func TestPhaseTimeoutsApplyToCallerClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name  string
+		setup func(c *DefaultIoTGatewayClient, rt http.RoundTripper)
+	}{
+		{"SetHTTPClient", func(c *DefaultIoTGatewayClient, rt http.RoundTripper) {
+			c.SetHTTPClient(&http.Client{Transport: rt})
+		}},
+		{"SetTransport", func(c *DefaultIoTGatewayClient, rt http.RoundTripper) {
+			c.SetTransport(rt)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewIoTGatewayClient(srv.URL, "app", "secret", "")
+			client.SetReadTimeout(50)
+			tt.setup(client, &countingTransport{next: http.DefaultTransport})
+
+			start := time.Now()
+			_, err := client.Execute(newTestRequest())
+			var timeoutErr *TimeoutError
+			if !errors.As(err, &timeoutErr) || timeoutErr.Phase != TIMEOUT_PHASE_FIRST_BYTE {
+				t.Fatalf("Execute() error = %v, want first_byte 超时", err)
+			}
+			if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+				t.Errorf("超时未及时生效，耗时 %s", elapsed)
+			}
+		})
+	}
+}
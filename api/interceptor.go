@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Call 一次网关调用，拦截器可以读取或修改其中的内容
+type Call struct {
+	// Request 原始请求
+	Request IoTGatewayRequest
+	// ApiName API名称
+	ApiName string
+	// ApiVer API版本
+	ApiVer string
+	// TransId 交易ID
+	TransId string
+	// URL 完整的请求URL
+	URL string
+	// Params BuildAppParams 之后的完整请求参数
+	Params map[string]interface{}
+	// Body 实际发送的请求报文，修改 Params 后需调用 EncodeParams 同步
+	Body string
+	// Header 附加的HTTP请求头
+	Header http.Header
+	// Attempt 尝试序号，从1开始，由重试拦截器设置
+	Attempt int
+	// Idempotent 请求是否可以安全地重复发送，为false时默认重试策略只重试请求发出前的失败
+	Idempotent bool
+
+	// resign 重试前刷新时间戳、令牌与交易ID，由客户端设置
+	resign func(call *Call) error
+}
+
+// EncodeParams 将 Params 重新序列化为请求报文
+func (c *Call) EncodeParams() error {
+	data, err := json.Marshal(c.Params)
+	if err != nil {
+		return err
+	}
+	c.Body = string(data)
+	return nil
+}
+
+// clone 复制调用，避免单次尝试中的修改影响后续重试
+//
+// Params 中嵌套的 map 与切片逐层复制，其他类型的值按原样共享。
+func (c *Call) clone() *Call {
+	cp := *c
+	cp.Header = c.Header.Clone()
+	if cp.Header == nil {
+		cp.Header = http.Header{}
+	}
+	if c.Params != nil {
+		cp.Params = copyParams(c.Params)
+	}
+	return &cp
+}
+
+// copyParams 深复制参数表
+func copyParams(params map[string]interface{}) map[string]interface{} {
+	cp := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		cp[k] = copyParamValue(v)
+	}
+	return cp
+}
+
+// copyParamValue 深复制参数值中的 map 与切片
+func copyParamValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return copyParams(val)
+	case []interface{}:
+		cp := make([]interface{}, len(val))
+		for i, item := range val {
+			cp[i] = copyParamValue(item)
+		}
+		return cp
+	case []map[string]interface{}:
+		cp := make([]map[string]interface{}, len(val))
+		for i, item := range val {
+			cp[i] = copyParams(item)
+		}
+		return cp
+	case []string:
+		return append([]string(nil), val...)
+	case map[string]string:
+		cp := make(map[string]string, len(val))
+		for k, item := range val {
+			cp[k] = item
+		}
+		return cp
+	default:
+		return val
+	}
+}
+
+// CallResult 网关调用的原始响应
+type CallResult struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+// Handler 处理一次网关调用
+type Handler func(ctx context.Context, call *Call) (*CallResult, error)
+
+// Interceptor 拦截器，可在调用 next 前后添加逻辑，也可以不调用 next 直接返回结果
+type Interceptor func(next Handler) Handler
+
+// Chain 将拦截器按顺序包装在 handler 外层，第一个拦截器最先执行
+func Chain(handler Handler, interceptors ...Interceptor) Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		if interceptors[i] != nil {
+			handler = interceptors[i](handler)
+		}
+	}
+	return handler
+}
+
+// LoggingInterceptor 记录每次尝试的请求与结果，日志字段在输出前已脱敏
+func LoggingInterceptor(logger Logger) Interceptor {
+	return func(next Handler) Handler {
+		if logger == nil {
+			return next
+		}
+		if _, ok := logger.(nopLogger); ok {
+			return next
+		}
+
+		return func(ctx context.Context, call *Call) (*CallResult, error) {
+			fields := []LogField{
+				Field(LOG_FIELD_API_NAME, call.ApiName),
+				Field(LOG_FIELD_API_VER, call.ApiVer),
+				Field(LOG_FIELD_TRANS_ID, call.TransId),
+				Field(LOG_FIELD_ATTEMPT, call.Attempt),
+			}
+			logRedacted(logger, LogLevelDebug, "发送网关请求", append(fields,
+				Field(LOG_FIELD_URL, call.URL),
+				Field(LOG_FIELD_REQUEST, call.Body),
+			)...)
+
+			start := time.Now()
+			result, err := next(ctx, call)
+			fields = append(fields, Field(LOG_FIELD_LATENCY, time.Since(start)))
+
+			if err != nil {
+				logRedacted(logger, LogLevelWarn, "网关请求失败", append(fields, Field(LOG_FIELD_ERROR, err))...)
+				return result, err
+			}
+
+			if result != nil {
+				fields = append(fields, Field(LOG_FIELD_STATUS_CODE, result.StatusCode))
+				logRedacted(logger, LogLevelDebug, "收到网关响应", append(fields, Field(LOG_FIELD_RESPONSE, result.Body))...)
+			}
+			logRedacted(logger, LogLevelInfo, "网关请求完成", fields...)
+			return result, nil
+		}
+	}
+}
+
+// logRedacted 对字段脱敏后输出日志
+func logRedacted(logger Logger, level LogLevel, msg string, fields ...LogField) {
+	logger.Log(level, msg, RedactFields(fields)...)
+}
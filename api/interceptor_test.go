@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCallCloneDeepCopiesParams(t *testing.T) {
+	call := &Call{
+		Params: map[string]interface{}{
+			"app_id": "app",
+			"data": map[string]interface{}{
+				"iccids": []string{"89860625680009634556"},
+				"items":  []interface{}{map[string]interface{}{"k": "v"}},
+			},
+		},
+	}
+
+	cp := call.clone()
+	data := cp.Params["data"].(map[string]interface{})
+	data["openId"] = "changed"
+	data["iccids"].([]string)[0] = "changed"
+	data["items"].([]interface{})[0].(map[string]interface{})["k"] = "changed"
+	cp.Params["app_id"] = "changed"
+	cp.Header.Set("X-Test", "1")
+
+	orig := call.Params["data"].(map[string]interface{})
+	if _, ok := orig["openId"]; ok {
+		t.Error("修改副本的 data 影响了原调用")
+	}
+	if orig["iccids"].([]string)[0] != "89860625680009634556" {
+		t.Error("修改副本的切片影响了原调用")
+	}
+	if orig["items"].([]interface{})[0].(map[string]interface{})["k"] != "v" {
+		t.Error("修改副本的嵌套 map 影响了原调用")
+	}
+	if call.Params["app_id"] != "app" {
+		t.Error("修改副本的参数影响了原调用")
+	}
+	if call.Header != nil {
+		t.Error("修改副本的请求头影响了原调用")
+	}
+}
+
+func TestRetryAttemptsDoNotShareParams(t *testing.T) {
+	var seen []interface{}
+	handler := func(ctx context.Context, call *Call) (*CallResult, error) {
+		seen = append(seen, call.Params["marker"])
+		return nil, &HTTPError{StatusCode: http.StatusServiceUnavailable}
+	}
+	mutate := func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*CallResult, error) {
+			if call.Params["marker"] == nil {
+				call.Params["marker"] = call.Attempt
+			}
+			return next(ctx, call)
+		}
+	}
+
+	policy := &ExponentialBackoffRetryPolicy{BaseDelay: time.Millisecond, Multiplier: 1}
+	h := Chain(handler, RetryInterceptor(policy, 1, nil), mutate)
+	h(context.Background(), &Call{Params: map[string]interface{}{}, Idempotent: true})
+
+	if len(seen) != 2 || seen[0] != 1 || seen[1] != 2 {
+		t.Errorf("每次尝试应看到独立的参数，实际 %v", seen)
+	}
+}
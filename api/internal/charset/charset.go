@@ -0,0 +1,101 @@
+package charset
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+// 支持的字符集
+const (
+	UTF8    = "UTF-8"
+	GBK     = "GBK"
+	GB18030 = "GB18030"
+)
+
+// UnsupportedCharsetError 不支持的字符集
+type UnsupportedCharsetError struct {
+	Charset string
+}
+
+// Error 实现error接口
+func (e *UnsupportedCharsetError) Error() string {
+	return fmt.Sprintf("不支持的字符集: %s", e.Charset)
+}
+
+// aliases 字符集别名（大写）到规范名称
+var aliases = map[string]string{
+	"UTF-8":   UTF8,
+	"UTF8":    UTF8,
+	"GBK":     GBK,
+	"CP936":   GBK,
+	"GB2312":  GBK, // GBK 是 GB2312 的超集
+	"GB18030": GB18030,
+}
+
+// encodings 非UTF-8字符集对应的编码
+var encodings = map[string]encoding.Encoding{
+	GBK:     simplifiedchinese.GBK,
+	GB18030: simplifiedchinese.GB18030,
+}
+
+// Normalize 返回字符集的规范名称，空串视为UTF-8，不支持时返回 UnsupportedCharsetError
+func Normalize(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return UTF8, nil
+	}
+	if canonical, ok := aliases[strings.ToUpper(name)]; ok {
+		return canonical, nil
+	}
+	return "", &UnsupportedCharsetError{Charset: name}
+}
+
+// Encode 将UTF-8字符串编码为指定字符集的字节，无法表示的字符返回错误
+func Encode(s, name string) ([]byte, error) {
+	canonical, err := Normalize(name)
+	if err != nil {
+		return nil, err
+	}
+	if canonical == UTF8 {
+		return []byte(s), nil
+	}
+
+	b, err := encodings[canonical].NewEncoder().Bytes([]byte(s))
+	if err != nil {
+		return nil, fmt.Errorf("无法以%s编码: %w", canonical, err)
+	}
+	return b, nil
+}
+
+// Decode 将指定字符集的字节解码为UTF-8字符串
+func Decode(b []byte, name string) (string, error) {
+	canonical, err := Normalize(name)
+	if err != nil {
+		return "", err
+	}
+	if canonical == UTF8 {
+		return string(b), nil
+	}
+
+	s, err := encodings[canonical].NewDecoder().Bytes(b)
+	if err != nil {
+		return "", fmt.Errorf("无法以%s解码: %w", canonical, err)
+	}
+	return string(s), nil
+}
+
+// FromContentType 获取 Content-Type 中的 charset 参数，没有时返回空串
+func FromContentType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return params["charset"]
+}
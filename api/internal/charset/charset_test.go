@@ -0,0 +1,102 @@
+package charset
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", UTF8},
+		{"utf-8", UTF8},
+		{"utf8", UTF8},
+		{" UTF-8 ", UTF8},
+		{"gbk", GBK},
+		{"cp936", GBK},
+		{"GB2312", GBK},
+		{"gb18030", GB18030},
+	}
+
+	for _, tt := range tests {
+		got, err := Normalize(tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("Normalize(%q) = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestUnsupportedCharset(t *testing.T) {
+	var csErr *UnsupportedCharsetError
+
+	_, err := Normalize("latin1")
+	if !errors.As(err, &csErr) || csErr.Charset != "latin1" {
+		t.Errorf("Normalize(latin1) error = %v, want UnsupportedCharsetError", err)
+	}
+	if _, err := Encode("中文", "big5"); !errors.As(err, &csErr) {
+		t.Errorf("Encode(big5) error = %v, want UnsupportedCharsetError", err)
+	}
+	if _, err := Decode([]byte("abc"), "shift_jis"); !errors.As(err, &csErr) {
+		t.Errorf("Decode(shift_jis) error = %v, want UnsupportedCharsetError", err)
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		charset string
+		text    string
+		want    []byte
+	}{
+		{UTF8, "中文", []byte("中文")},
+		{GBK, "中文", []byte{0xd6, 0xd0, 0xce, 0xc4}},
+		{GBK, "abc", []byte("abc")},
+		{GB18030, "😀", []byte{0x94, 0x39, 0xfc, 0x36}},
+	}
+
+	for _, tt := range tests {
+		b, err := Encode(tt.text, tt.charset)
+		if err != nil {
+			t.Fatalf("Encode(%q, %s) error = %v", tt.text, tt.charset, err)
+		}
+		if !bytes.Equal(b, tt.want) {
+			t.Errorf("Encode(%q, %s) = % x, want % x", tt.text, tt.charset, b, tt.want)
+		}
+
+		s, err := Decode(b, tt.charset)
+		if err != nil {
+			t.Fatalf("Decode(% x, %s) error = %v", b, tt.charset, err)
+		}
+		if s != tt.text {
+			t.Errorf("Decode(% x, %s) = %q, want %q", b, tt.charset, s, tt.text)
+		}
+	}
+}
+
+func TestEncodeUnrepresentableRune(t *testing.T) {
+	if _, err := Encode("表情😀", GBK); err == nil {
+		t.Error("GBK 无法表示 emoji，Encode 应返回错误")
+	}
+}
+
+func TestFromContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        string
+	}{
+		{"", ""},
+		{"application/json", ""},
+		{"application/json;charset=GBK", "GBK"},
+		{"application/json; charset=utf-8", "utf-8"},
+		{`text/plain; charset="gb18030"`, "gb18030"},
+		{"invalid;;", ""},
+	}
+
+	for _, tt := range tests {
+		if got := FromContentType(tt.contentType); got != tt.want {
+			t.Errorf("FromContentType(%q) = %q, want %q", tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -1,12 +1,10 @@
 package utils
 
 import (
-	"crypto/md5"
-	"encoding/base64"
 	"fmt"
-	"sort"
-	"strings"
 	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api/sign"
 )
 
 const (
@@ -19,10 +17,11 @@ const (
 	OpenIDKey    = "openId"
 )
 
-// BuildAppParams 构建应用参数
-func BuildAppParams(params map[string]interface{}) error {
-	appID, ok := params[AppIDKey].(string)
-	if !ok {
+// BuildAppParams 以 now 为时间戳构建应用参数并用 signer 签名，signer 为nil时使用SM3令牌
+//
+// params 中已有 trans_id 时沿用，否则使用默认序列生成；签名后移除密钥参数。
+func BuildAppParams(params map[string]interface{}, now time.Time, signer sign.Signer) error {
+	if _, ok := params[AppIDKey].(string); !ok {
 		return fmt.Errorf("app_id 参数类型不正确")
 	}
 
@@ -31,75 +30,34 @@ func BuildAppParams(params map[string]interface{}) error {
 		return fmt.Errorf("app_secrect 参数类型不正确")
 	}
 
-	// 使用SM3生成token和其他参数
-	result, err := MakeToken(appID, appSecret)
-	if err != nil {
-		return err
+	transID, _ := params[TransIDKey].(string)
+	if transID == "" {
+		transID = NewTransIDAt(now)
 	}
 
 	// 设置参数
-	params["token"] = result["token"]
-	params["trans_id"] = result["trans_id"]
-	params["timestamp"] = result["timestamp"]
+	params[TransIDKey] = transID
+	params[TimestampKey] = FormatDateString(now, 8)
 
 	// 移除密钥参数
 	delete(params, AppSecretKey)
 
-	return nil
-}
-
-// CreateSign 创建签名
-func CreateSign(characterEncoding string, parameters map[string]interface{}) (string, error) {
-	// 按照键排序参数
-	var keys []string
-	for k := range parameters {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	// 构建签名字符串
-	var sb strings.Builder
-	for _, k := range keys {
-		v := parameters[k]
-		if v == nil || (AppSecretKey == k) {
-			continue
-		}
-
-		// 处理字符串数组
-		if strArray, ok := v.([]string); ok && len(strArray) > 0 {
-			v = strArray[0]
-		}
-
-		// 将值转换为字符串并追加到签名字符串
-		if str, ok := v.(string); ok && str != "" {
-			sb.WriteString(k)
-			sb.WriteString(str)
-		}
-	}
-
-	// 添加密钥
-	if appSecret, ok := parameters[AppSecretKey].(string); ok {
-		sb.WriteString(appSecret)
+	if signer == nil {
+		signer = sign.Default()
 	}
-
-	// 计算MD5并进行Base64编码
-	return getMD5Encode(characterEncoding, sb.String())
+	return sign.Apply(signer, params, appSecret)
 }
 
-// getMD5Encode MD5摘要计算并进行Base64编码
-func getMD5Encode(characterEncoding string, md5Code string) (string, error) {
-	h := md5.New()
-	h.Write([]byte(md5Code))
-	sum := h.Sum(nil)
-	return base64.StdEncoding.EncodeToString(sum), nil
-}
+// FormatDateString 在指定时区格式化时间，如 "2006-01-02 15:04:05 000"
+// timeZoneOffset 表示时区，如中国一般使用东八区，因此timeZoneOffset就是8
+func FormatDateString(t time.Time, timeZoneOffset float32) string {
+	if timeZoneOffset > 13 || timeZoneOffset < -12 {
+		timeZoneOffset = 0
+	}
 
-// GetTimestampFormat 返回时间戳格式
-func GetTimestampFormat() string {
-	return "2006-01-02 15:04:05.000"
-}
+	// 设置时区
+	loc := time.FixedZone(fmt.Sprintf("UTC%+.1f", timeZoneOffset), int(timeZoneOffset*3600))
 
-// GetTimestamp 获取当前时间戳字符串
-func GetTimestamp() string {
-	return time.Now().Format(GetTimestampFormat())
+	// 格式化时间
+	return t.In(loc).Format("2006-01-02 15:04:05 000")
 }
@@ -0,0 +1,214 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+const (
+	// 超时阶段
+	PhaseConnect      = "connect"       // 获取连接、建立TCP连接
+	PhaseTLSHandshake = "tls_handshake" // TLS握手
+	PhaseFirstByte    = "first_byte"    // 发送请求并等待响应首字节
+	PhaseReadBody     = "read_body"     // 读取响应体
+	PhaseAttempt      = "attempt"       // 单次尝试的总时长
+)
+
+// Timeouts 单次尝试的超时配置，0表示不限制
+type Timeouts struct {
+	// Connect 连接超时，只约束建立连接与TLS握手
+	Connect time.Duration
+	// Read 读取超时，约束等待响应首字节以及响应体两次读取之间的间隔
+	Read time.Duration
+	// Attempt 单次尝试的总截止时间
+	Attempt time.Duration
+}
+
+// TimeoutError 超时错误，Phase 表示超时发生的阶段
+type TimeoutError struct {
+	Phase    string
+	Duration time.Duration
+	Cause    error
+}
+
+// Error 实现error接口
+func (e *TimeoutError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s阶段超时(%s): %v", e.Phase, e.Duration, e.Cause)
+	}
+	return fmt.Sprintf("%s阶段超时(%s)", e.Phase, e.Duration)
+}
+
+// Timeout 实现 net.Error 的超时判断
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// Is 使 errors.Is(err, context.DeadlineExceeded) 能识别超时错误
+func (e *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// NotSentError 请求在写出请求头之前失败（如建立连接、TLS握手失败），网关不可能收到该请求
+type NotSentError struct {
+	Cause error
+}
+
+// Error 实现error接口
+func (e *NotSentError) Error() string {
+	return fmt.Sprintf("请求未发出: %v", e.Cause)
+}
+
+// Unwrap 返回原始错误
+func (e *NotSentError) Unwrap() error {
+	return e.Cause
+}
+
+// attempt 单次HTTP尝试的分阶段超时控制
+type attempt struct {
+	parent   context.Context
+	ctx      context.Context
+	cancel   context.CancelFunc
+	timeouts Timeouts
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	phase   string
+	limit   time.Duration
+	expired bool
+	wrote   bool
+}
+
+// startAttempt 开始一次尝试，返回的上下文会在任一阶段超时时被取消
+func startAttempt(parent context.Context, timeouts Timeouts) *attempt {
+	a := &attempt{
+		parent:   parent,
+		timeouts: timeouts,
+	}
+
+	if timeouts.Attempt > 0 {
+		a.ctx, a.cancel = context.WithTimeout(parent, timeouts.Attempt)
+	} else {
+		a.ctx, a.cancel = context.WithCancel(parent)
+	}
+
+	trace := &httptrace.ClientTrace{
+		ConnectStart: func(string, string) {
+			a.setPhase(PhaseConnect)
+		},
+		TLSHandshakeStart: func() {
+			a.setPhase(PhaseTLSHandshake)
+		},
+		GotConn: func(httptrace.GotConnInfo) {
+			a.arm(PhaseFirstByte, timeouts.Read)
+		},
+		WroteHeaders: func() {
+			a.mu.Lock()
+			a.wrote = true
+			a.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			a.arm(PhaseReadBody, timeouts.Read)
+		},
+	}
+	a.ctx = httptrace.WithClientTrace(a.ctx, trace)
+
+	a.arm(PhaseConnect, timeouts.Connect)
+	return a
+}
+
+// arm 进入新的阶段并重新计时
+func (a *attempt) arm(phase string, d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.expired {
+		return
+	}
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	a.phase = phase
+	a.limit = d
+	if d > 0 {
+		a.timer = time.AfterFunc(d, a.expire)
+	}
+}
+
+// setPhase 切换阶段名称，沿用当前计时
+func (a *attempt) setPhase(phase string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.expired {
+		a.phase = phase
+	}
+}
+
+// expire 当前阶段超时，取消本次尝试
+func (a *attempt) expire() {
+	a.mu.Lock()
+	a.expired = true
+	a.mu.Unlock()
+	a.cancel()
+}
+
+// finish 结束本次尝试并释放计时器
+func (a *attempt) finish() {
+	a.mu.Lock()
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	a.mu.Unlock()
+	a.cancel()
+}
+
+// classify 将因超时导致的错误转换为 TimeoutError，请求头写出前的错误再包装为 NotSentError
+func (a *attempt) classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// 调用方上下文结束时由上层处理
+	if a.parent.Err() != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	expired, phase, limit, wrote := a.expired, a.phase, a.limit, a.wrote
+	a.mu.Unlock()
+
+	if expired {
+		err = &TimeoutError{Phase: phase, Duration: limit, Cause: err}
+	} else if a.ctx.Err() == context.DeadlineExceeded {
+		err = &TimeoutError{Phase: PhaseAttempt, Duration: a.timeouts.Attempt, Cause: err}
+	}
+
+	if !wrote {
+		return &NotSentError{Cause: err}
+	}
+	return err
+}
+
+// wrapBody 包装响应体，每次读取前重新开始读取超时计时
+func (a *attempt) wrapBody(body io.ReadCloser) io.ReadCloser {
+	return &timedBody{ReadCloser: body, attempt: a}
+}
+
+// timedBody 带读取超时的响应体
+type timedBody struct {
+	io.ReadCloser
+	attempt *attempt
+}
+
+// Read 实现io.Reader接口
+func (b *timedBody) Read(p []byte) (int, error) {
+	b.attempt.arm(PhaseReadBody, b.attempt.timeouts.Read)
+	return b.ReadCloser.Read(p)
+}
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// assertTimeout 检查错误是否为指定阶段的 TimeoutError，且在限制时间附近返回
+func assertTimeout(t *testing.T, err error, elapsed time.Duration, phase string, limit time.Duration) {
+	t.Helper()
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("error = %v, want TimeoutError", err)
+	}
+	if timeoutErr.Phase != phase {
+		t.Errorf("Phase = %s, want %s", timeoutErr.Phase, phase)
+	}
+	if timeoutErr.Duration != limit {
+		t.Errorf("Duration = %s, want %s", timeoutErr.Duration, limit)
+	}
+	if elapsed < limit || elapsed > limit+time.Second {
+		t.Errorf("耗时 %s, want 约 %s", elapsed, limit)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("TimeoutError 应满足 errors.Is(err, context.DeadlineExceeded)")
+	}
+}
+
+// stallingListener 接受连接后不做任何响应，用于模拟TLS握手卡住
+func stallingListener(t *testing.T) (string, func()) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	var conns []net.Conn
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+	return ln.Addr().String(), func() {
+		ln.Close()
+		<-done
+		for _, c := range conns {
+			c.Close()
+		}
+	}
+}
+
+func TestConnectTimeout(t *testing.T) {
+	// 拦截拨号并阻塞，模拟连接建立卡住
+	transport := NewTransport(DefaultTransportConfig())
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	client := NewHTTPClient(transport)
+
+	limit := 100 * time.Millisecond
+	start := time.Now()
+	_, err := ExecutePost(context.Background(), client, "http://127.0.0.1:1/", "text/plain", nil, nil, Timeouts{Connect: limit, Read: time.Minute})
+	assertTimeout(t, err, time.Since(start), PhaseConnect, limit)
+
+	var notSent *NotSentError
+	if !errors.As(err, &notSent) {
+		t.Errorf("连接超时应包装为 NotSentError, 实际 %v", err)
+	}
+}
+
+func TestTLSHandshakeTimeout(t *testing.T) {
+	addr, stop := stallingListener(t)
+	defer stop()
+
+	limit := 100 * time.Millisecond
+	start := time.Now()
+	_, err := ExecutePost(context.Background(), nil, "https://"+addr+"/", "text/plain", nil, nil, Timeouts{Connect: limit, Read: time.Minute})
+	assertTimeout(t, err, time.Since(start), PhaseTLSHandshake, limit)
+
+	var notSent *NotSentError
+	if !errors.As(err, &notSent) {
+		t.Errorf("TLS握手超时应包装为 NotSentError, 实际 %v", err)
+	}
+}
+
+func TestFirstByteTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	limit := 100 * time.Millisecond
+	start := time.Now()
+	_, err := ExecutePost(context.Background(), nil, srv.URL, "text/plain", []byte("x"), nil, Timeouts{Connect: time.Second, Read: limit})
+	assertTimeout(t, err, time.Since(start), PhaseFirstByte, limit)
+
+	var notSent *NotSentError
+	if errors.As(err, &notSent) {
+		t.Error("请求已发出，首字节超时不应包装为 NotSentError")
+	}
+}
+
+func TestReadBodyTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	limit := 100 * time.Millisecond
+	start := time.Now()
+	_, err := ExecutePost(context.Background(), nil, srv.URL, "text/plain", nil, nil, Timeouts{Read: limit})
+	assertTimeout(t, err, time.Since(start), PhaseReadBody, limit)
+}
+
+func TestAttemptTimeout(t *testing.T) {
+	// 每次写入间隔短于读取超时，但总时长超过单次尝试上限
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 10; i++ {
+			w.Write([]byte("x"))
+			w.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	limit := 200 * time.Millisecond
+	start := time.Now()
+	_, err := ExecutePost(context.Background(), nil, srv.URL, "text/plain", nil, nil, Timeouts{Read: 150 * time.Millisecond, Attempt: limit})
+	assertTimeout(t, err, time.Since(start), PhaseAttempt, limit)
+}
+
+func TestSlowBodyWithinReadTimeoutIsNotCut(t *testing.T) {
+	// 总时长超过读取超时，但每次读取的间隔都在限制之内
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 5; i++ {
+			w.Write([]byte("chunk;"))
+			w.(http.Flusher).Flush()
+			time.Sleep(60 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	resp, err := ExecutePost(context.Background(), nil, srv.URL, "text/plain", nil, nil, Timeouts{Read: 150 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("ExecutePost() error = %v", err)
+	}
+	if resp.Body != strings.Repeat("chunk;", 5) {
+		t.Errorf("响应被截断: %q", resp.Body)
+	}
+}
+
+func TestCallerCancelIsNotTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := ExecutePost(ctx, nil, srv.URL, "text/plain", nil, nil, Timeouts{Read: time.Minute})
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) || !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// DefaultMinTLSVersion 默认最低TLS版本
+const DefaultMinTLSVersion = tls.VersionTLS12
+
+// TLSOptions TLS配置选项
+type TLSOptions struct {
+	// RootCAFile 自定义根证书文件（PEM格式）
+	RootCAFile string
+	// RootCAPEM 自定义根证书内容（PEM格式）
+	RootCAPEM []byte
+	// ClientCertFile 双向认证的客户端证书文件
+	ClientCertFile string
+	// ClientKeyFile 双向认证的客户端私钥文件
+	ClientKeyFile string
+	// ClientCertPEM 双向认证的客户端证书内容
+	ClientCertPEM []byte
+	// ClientKeyPEM 双向认证的客户端私钥内容
+	ClientKeyPEM []byte
+	// MinVersion 最低TLS版本，0表示使用默认值
+	MinVersion uint16
+	// InsecureSkipVerify 跳过证书校验，仅用于测试环境
+	InsecureSkipVerify bool
+}
+
+// BuildTLSConfig 根据选项构建TLS配置，默认校验服务端证书
+func BuildTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         opts.MinVersion,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+	if cfg.MinVersion == 0 {
+		cfg.MinVersion = DefaultMinTLSVersion
+	}
+
+	// 自定义根证书
+	if opts.RootCAFile != "" || len(opts.RootCAPEM) > 0 {
+		pool := x509.NewCertPool()
+		if opts.RootCAFile != "" {
+			pemData, err := ioutil.ReadFile(opts.RootCAFile)
+			if err != nil {
+				return nil, fmt.Errorf("读取根证书文件失败: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pemData) {
+				return nil, fmt.Errorf("根证书文件中没有有效的PEM证书: %s", opts.RootCAFile)
+			}
+		}
+		if len(opts.RootCAPEM) > 0 && !pool.AppendCertsFromPEM(opts.RootCAPEM) {
+			return nil, fmt.Errorf("根证书内容中没有有效的PEM证书")
+		}
+		cfg.RootCAs = pool
+	}
+
+	// 双向认证客户端证书
+	switch {
+	case opts.ClientCertFile != "" || opts.ClientKeyFile != "":
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载客户端证书失败: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	case len(opts.ClientCertPEM) > 0 || len(opts.ClientKeyPEM) > 0:
+		cert, err := tls.X509KeyPair(opts.ClientCertPEM, opts.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("解析客户端证书失败: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
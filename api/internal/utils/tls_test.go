@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newClientCert 生成用于双向认证的自签名客户端证书与私钥（PEM格式）
+func newClientCert(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成私钥失败: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "iot-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("生成证书失败: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("编码私钥失败: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeTemp 将内容写入临时目录中的文件
+func writeTemp(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+	return path
+}
+
+// get 使用给定TLS选项访问服务器
+func get(t *testing.T, srv *httptest.Server, opts TLSOptions) error {
+	t.Helper()
+	_, err := DoGet(context.Background(), clientFor(t, opts), srv.URL, nil, Timeouts{})
+	return err
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+}
+
+func TestBuildTLSConfigSecureDefault(t *testing.T) {
+	cfg, err := BuildTLSConfig(TLSOptions{})
+	if err != nil {
+		t.Fatalf("BuildTLSConfig() error = %v", err)
+	}
+	if cfg.MinVersion != tls.VersionTLS12 || cfg.InsecureSkipVerify || cfg.RootCAs != nil || len(cfg.Certificates) != 0 {
+		t.Errorf("默认配置不安全: MinVersion=%x InsecureSkipVerify=%v", cfg.MinVersion, cfg.InsecureSkipVerify)
+	}
+
+	cfg, _ = BuildTLSConfig(TLSOptions{MinVersion: tls.VersionTLS13})
+	if cfg.MinVersion != tls.VersionTLS13 {
+		t.Errorf("MinVersion = %x, want TLS1.3", cfg.MinVersion)
+	}
+
+	srv := httptest.NewTLSServer(okHandler())
+	defer srv.Close()
+	if err := get(t, srv, TLSOptions{}); err == nil {
+		t.Error("默认配置应拒绝不受信任的证书")
+	}
+}
+
+func TestBuildTLSConfigMinVersion(t *testing.T) {
+	srv := httptest.NewUnstartedServer(okHandler())
+	srv.TLS = &tls.Config{MinVersion: tls.VersionTLS12, MaxVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	ca := serverCertPEM(srv)
+	if err := get(t, srv, TLSOptions{RootCAPEM: ca}); err != nil {
+		t.Errorf("TLS1.2服务器 error = %v", err)
+	}
+	if err := get(t, srv, TLSOptions{RootCAPEM: ca, MinVersion: tls.VersionTLS13}); err == nil {
+		t.Error("要求TLS1.3时应拒绝只支持TLS1.2的服务器")
+	}
+}
+
+func TestBuildTLSConfigCustomCA(t *testing.T) {
+	srv := httptest.NewTLSServer(okHandler())
+	defer srv.Close()
+
+	ca := serverCertPEM(srv)
+	if err := get(t, srv, TLSOptions{RootCAPEM: ca}); err != nil {
+		t.Errorf("RootCAPEM error = %v", err)
+	}
+	if err := get(t, srv, TLSOptions{RootCAFile: writeTemp(t, "ca.pem", ca)}); err != nil {
+		t.Errorf("RootCAFile error = %v", err)
+	}
+
+	// 只信任其他CA时拒绝服务器证书
+	otherCA, _ := newClientCert(t)
+	if err := get(t, srv, TLSOptions{RootCAPEM: otherCA}); err == nil {
+		t.Error("自定义根证书不应信任其他CA")
+	}
+}
+
+func TestBuildTLSConfigInvalidOptions(t *testing.T) {
+	certPEM, _ := newClientCert(t)
+	tests := []struct {
+		name string
+		opts TLSOptions
+	}{
+		{"无效的根证书内容", TLSOptions{RootCAPEM: []byte("not pem")}},
+		{"根证书文件不存在", TLSOptions{RootCAFile: filepath.Join(t.TempDir(), "missing.pem")}},
+		{"根证书文件无效", TLSOptions{RootCAFile: writeTemp(t, "bad.pem", []byte("bad"))}},
+		{"客户端证书缺少私钥", TLSOptions{ClientCertPEM: certPEM}},
+		{"客户端证书文件不存在", TLSOptions{ClientCertFile: "missing.crt", ClientKeyFile: "missing.key"}},
+	}
+
+	for _, tt := range tests {
+		if _, err := BuildTLSConfig(tt.opts); err == nil {
+			t.Errorf("%s: BuildTLSConfig() 应返回错误", tt.name)
+		}
+	}
+}
+
+func TestBuildTLSConfigMutualTLS(t *testing.T) {
+	certPEM, keyPEM := newClientCert(t)
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(certPEM)
+
+	srv := httptest.NewUnstartedServer(okHandler())
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	srv.StartTLS()
+	defer srv.Close()
+
+	ca := serverCertPEM(srv)
+	if err := get(t, srv, TLSOptions{RootCAPEM: ca}); err == nil {
+		t.Error("未提供客户端证书时服务器应拒绝")
+	}
+	if err := get(t, srv, TLSOptions{RootCAPEM: ca, ClientCertPEM: certPEM, ClientKeyPEM: keyPEM}); err != nil {
+		t.Errorf("ClientCertPEM error = %v", err)
+	}
+
+	opts := TLSOptions{
+		RootCAPEM:      ca,
+		ClientCertFile: writeTemp(t, "client.crt", certPEM),
+		ClientKeyFile:  writeTemp(t, "client.key", keyPEM),
+	}
+	if err := get(t, srv, opts); err != nil {
+		t.Errorf("ClientCertFile error = %v", err)
+	}
+}
+
+func TestBuildTLSConfigInsecureOptIn(t *testing.T) {
+	srv := httptest.NewTLSServer(okHandler())
+	defer srv.Close()
+
+	if err := get(t, srv, TLSOptions{InsecureSkipVerify: true}); err != nil {
+		t.Errorf("InsecureSkipVerify error = %v", err)
+	}
+}
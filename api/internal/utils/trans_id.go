@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// TransIDLength 默认交易ID长度：17位东八区毫秒时间戳 + 6位序号
+	TransIDLength = 23
+
+	// transIDSequenceMod 序号取值范围
+	transIDSequenceMod = 1000000
+)
+
+// transIDLocation 交易ID时间戳使用的东八区时区
+var transIDLocation = time.FixedZone("GMT+8", 8*3600)
+
+// defaultTransIDSequence 进程内共享的默认交易ID序列
+var defaultTransIDSequence = NewTransIDSequence()
+
+// TransIDSequence 交易ID序列，生成 yyyyMMddHHmmssSSS + 6位零填充序号 的定长ID
+//
+// 序号从加密随机数开始单调递增，同一进程内每毫秒最多一百万个ID不会重复，
+// 不同进程的起点随机，避免时间戳相同时互相冲突。可并发使用。
+type TransIDSequence struct {
+	counter uint32
+}
+
+// NewTransIDSequence 创建一个以加密随机数为起点的交易ID序列
+func NewTransIDSequence() *TransIDSequence {
+	var seed [4]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		// 系统随机源不可用时退化为纳秒时间
+		binary.BigEndian.PutUint32(seed[:], uint32(time.Now().UnixNano()))
+	}
+	return &TransIDSequence{counter: binary.BigEndian.Uint32(seed[:])}
+}
+
+// NextTransID 以系统当前时间生成下一个交易ID
+func (s *TransIDSequence) NextTransID() string {
+	return s.NextTransIDAt(time.Now())
+}
+
+// NextTransIDAt 以 now 为时间戳生成下一个交易ID
+func (s *TransIDSequence) NextTransIDAt(now time.Time) string {
+	now = now.In(transIDLocation)
+	seq := atomic.AddUint32(&s.counter, 1) % transIDSequenceMod
+	return fmt.Sprintf("%s%03d%06d", now.Format("20060102150405"), now.Nanosecond()/int(time.Millisecond), seq)
+}
+
+// NewTransID 使用进程内共享的默认序列生成交易ID
+func NewTransID() string {
+	return defaultTransIDSequence.NextTransID()
+}
+
+// NewTransIDAt 使用进程内共享的默认序列以 now 为时间戳生成交易ID
+func NewTransIDAt(now time.Time) string {
+	return defaultTransIDSequence.NextTransIDAt(now)
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// 连接池默认配置
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultKeepAlive           = 30 * time.Second
+)
+
+// TransportConfig 连接池与传输层配置
+type TransportConfig struct {
+	// MaxIdleConns 全部主机的最大空闲连接数
+	MaxIdleConns int
+	// MaxIdleConnsPerHost 每个主机的最大空闲连接数
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout 空闲连接保留时长
+	IdleConnTimeout time.Duration
+	// EnableHTTP2 是否尝试使用HTTP/2
+	EnableHTTP2 bool
+	// TLSClientConfig TLS配置
+	TLSClientConfig *tls.Config
+}
+
+// DefaultTransportConfig 返回默认的连接池配置
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+		EnableHTTP2:         true,
+		TLSClientConfig:     &tls.Config{MinVersion: DefaultMinTLSVersion},
+	}
+}
+
+// NewTransport 按配置创建可复用的 http.Transport，连接与TLS会话在请求间共享
+func NewTransport(cfg TransportConfig) *http.Transport {
+	dialer := &net.Dialer{
+		KeepAlive: DefaultKeepAlive,
+	}
+
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dialer.DialContext,
+		MaxIdleConns:        cfg.MaxIdleConns,
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:     cfg.IdleConnTimeout,
+		ForceAttemptHTTP2:   cfg.EnableHTTP2,
+	}
+
+	if cfg.TLSClientConfig != nil {
+		transport.TLSClientConfig = cfg.TLSClientConfig.Clone()
+		// 复用TLS会话，减少握手开销
+		if transport.TLSClientConfig.ClientSessionCache == nil {
+			transport.TLSClientConfig.ClientSessionCache = tls.NewLRUClientSessionCache(0)
+		}
+	}
+
+	// 禁用HTTP/2时清空协议升级表，确保只使用HTTP/1.1
+	if !cfg.EnableHTTP2 {
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport
+}
+
+// NewHTTPClient 使用给定的 RoundTripper 创建 http.Client
+func NewHTTPClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: transport,
+	}
+}
@@ -3,17 +3,19 @@ package utils
 import (
 	"bytes"
 	"compress/gzip"
-	"crypto/tls"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"path/filepath"
 	"strings"
-	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api/internal/charset"
 )
 
 const (
@@ -25,40 +27,39 @@ const (
 	DefaultCharset = "UTF-8"
 )
 
-// TrustAllCerts 信任所有证书
-var TrustAllCerts = &tls.Config{
-	InsecureSkipVerify: true,
-}
+// HTTPClient 未指定客户端时共享的默认HTTP客户端
+var HTTPClient = NewHTTPClient(NewTransport(DefaultTransportConfig()))
 
-// HTTPClient HTTP客户端，可根据需要进行配置
-var HTTPClient = &http.Client{
-	Transport: &http.Transport{
-		TLSClientConfig: TrustAllCerts,
-	},
-}
-
-// DoPost 执行HTTP POST请求
-func DoPost(serverURL, apiName, apiVersion, reqText string, connectTimeout, readTimeout, retryCount int) (string, error) {
-	// 确保URL以"/"结尾
-	if !strings.HasSuffix(serverURL, "/") {
-		serverURL += "/"
+// resolveClient 未指定客户端时使用共享的默认客户端
+func resolveClient(client *http.Client) *http.Client {
+	if client == nil {
+		return HTTPClient
 	}
+	return client
+}
 
-	// 去除"cn."前缀（如果存在）
-	if strings.HasPrefix(apiName, "cn.") {
-		apiName = apiName[3:]
-	}
+// HTTPResponse HTTP响应
+type HTTPResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
 
-	// 构建完整URL
-	fullURL := serverURL + strings.ReplaceAll(apiName, ".", "/") + "/v" + apiVersion
-	contentType := "application/json;charset=" + DefaultCharset
+// HTTPError HTTP状态码错误（状态码>=400）
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       string
+}
 
-	// 执行POST请求
-	return doPost(fullURL, contentType, []byte(reqText), connectTimeout, readTimeout, retryCount)
+// Error 实现error接口
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP错误: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
 }
 
-// DoPostWithParams 带参数执行HTTP POST请求
-func DoPostWithParams(serverURL, apiName, apiVersion string, params map[string]interface{}, connectTimeout, readTimeout, retryCount int) (string, error) {
+// BuildPostURL 根据API名称和版本构建完整的请求URL
+func BuildPostURL(serverURL, apiName, apiVersion string) string {
 	// 确保URL以"/"结尾
 	if !strings.HasSuffix(serverURL, "/") {
 		serverURL += "/"
@@ -70,153 +71,41 @@ func DoPostWithParams(serverURL, apiName, apiVersion string, params map[string]i
 	}
 
 	// 构建完整URL
-	fullURL := serverURL + strings.ReplaceAll(apiName, ".", "/") + "/v" + apiVersion
-	contentType := "application/json;charset=" + DefaultCharset
-
-	// 将参数转换为JSON
-	jsonData, err := json.Marshal(params)
-	if err != nil {
-		return "", err
-	}
-
-	// 执行POST请求
-	return doPost(fullURL, contentType, jsonData, connectTimeout, readTimeout, retryCount)
+	return serverURL + strings.ReplaceAll(apiName, ".", "/") + "/v" + apiVersion
 }
 
-// doPost 执行HTTP POST请求（内部方法）
-func doPost(url, contentType string, content []byte, connectTimeout, readTimeout, retryCount int) (string, error) {
-	var resp string
-	var err error
-
-	// 重试逻辑
-	for i := 0; i <= retryCount; i++ {
-		resp, err = executePost(url, contentType, content, connectTimeout, readTimeout)
-		if err == nil {
-			return resp, nil
-		}
-
-		// 最后一次重试失败，直接返回错误
-		if i == retryCount {
-			return "", err
-		}
-	}
-
-	return resp, err
+// ExecutePost 向完整URL执行单个HTTP POST请求，header 中的请求头会覆盖默认值
+func ExecutePost(ctx context.Context, client *http.Client, urlStr, contentType string, content []byte, header http.Header, timeouts Timeouts) (*HTTPResponse, error) {
+	return execute(ctx, client, MethodPost, urlStr, contentType, bytes.NewReader(content), header, "", timeouts)
 }
 
-// executePost 执行单个HTTP POST请求
-func executePost(urlStr, contentType string, content []byte, connectTimeout, readTimeout int) (string, error) {
-	// 创建请求
-	req, err := http.NewRequest(MethodPost, urlStr, bytes.NewBuffer(content))
-	if err != nil {
-		return "", err
-	}
-
-	// 设置请求头
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("User-Agent", "iot-gateway-sdk-go")
-	req.Header.Set("Accept", "text/xml,text/javascript")
-	fmt.Println(fmt.Sprintf("请求url: %s \n 请求体: %s", req.URL.String(), string(content)))
-	// 设置超时
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: TrustAllCerts,
-		},
-		Timeout: time.Duration(connectTimeout+readTimeout) * time.Millisecond,
-	}
-
-	// 发送请求
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	// 检查响应状态
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("HTTP错误: %d %s", resp.StatusCode, resp.Status)
-	}
-
-	// 处理gzip压缩
-	var reader io.ReadCloser
-	switch resp.Header.Get("Content-Encoding") {
-	case "gzip":
-		reader, err = gzip.NewReader(resp.Body)
-		if err != nil {
-			return "", err
-		}
-		defer reader.Close()
-	default:
-		reader = resp.Body
-	}
-
-	// 读取响应内容
-	body, err := ioutil.ReadAll(reader)
-	if err != nil {
-		return "", err
-	}
-
-	return string(body), nil
-}
-
-// DoGet 执行HTTP GET请求
-func DoGet(urlStr string, params map[string]string) (string, error) {
-	return DoGetWithCharset(urlStr, params, DefaultCharset)
+// DoGet 执行单个HTTP GET请求，参数按UTF-8编码
+func DoGet(ctx context.Context, client *http.Client, urlStr string, params map[string]string, timeouts Timeouts) (*HTTPResponse, error) {
+	return DoGetWithCharset(ctx, client, urlStr, params, DefaultCharset, timeouts)
 }
 
-// DoGetWithCharset 带字符集执行HTTP GET请求
-func DoGetWithCharset(urlStr string, params map[string]string, charset string) (string, error) {
+// DoGetWithCharset 带字符集执行单个HTTP GET请求，参数按 cs 编码后再进行URL编码，响应未声明字符集时按 cs 解码
+func DoGetWithCharset(ctx context.Context, client *http.Client, urlStr string, params map[string]string, cs string, timeouts Timeouts) (*HTTPResponse, error) {
 	// 构建完整URL
-	fullURL, err := BuildGetURL(urlStr, params, charset)
+	fullURL, err := BuildGetURL(urlStr, params, cs)
 	if err != nil {
-		return "", err
-	}
-
-	// 创建请求
-	req, err := http.NewRequest(MethodGet, fullURL, nil)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// 设置请求头
-	req.Header.Set("User-Agent", "iot-gateway-sdk-go")
-	req.Header.Set("Accept", "text/xml,text/javascript")
+	return execute(ctx, client, MethodGet, fullURL, "", nil, nil, cs, timeouts)
+}
 
-	// 发送请求
-	resp, err := HTTPClient.Do(req)
-	if err != nil {
+// BuildGetURL 构建GET请求URL，参数值按 cs 编码后再进行URL编码
+func BuildGetURL(urlStr string, params map[string]string, cs string) (string, error) {
+	if _, err := charset.Normalize(cs); err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
 
-	// 检查响应状态
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("HTTP错误: %d %s", resp.StatusCode, resp.Status)
-	}
-
-	// 读取响应内容
-	body, err := ioutil.ReadAll(resp.Body)
+	// 构建查询字符串
+	query, err := encodeValues(params, cs)
 	if err != nil {
 		return "", err
 	}
-
-	return string(body), nil
-}
-
-// BuildGetURL 构建GET请求URL
-func BuildGetURL(urlStr string, params map[string]string, charset string) (string, error) {
-	// 构建查询字符串
-	if len(params) == 0 {
-		return urlStr, nil
-	}
-
-	query := url.Values{}
-	for k, v := range params {
-		if k != "" && v != "" {
-			query.Add(k, v)
-		}
-	}
-
 	queryStr := query.Encode()
 	if queryStr == "" {
 		return urlStr, nil
@@ -232,137 +121,194 @@ func BuildGetURL(urlStr string, params map[string]string, charset string) (strin
 	return urlStr + "?" + queryStr, nil
 }
 
-// DoPostWithFile 执行带文件上传的HTTP POST请求
-func DoPostWithFile(urlStr string, textParams map[string]string, fileParams map[string]*FileItem, charset string, connectTimeout, readTimeout int) (string, error) {
-	// 如果没有文件参数，执行普通POST请求
-	if len(fileParams) == 0 {
-		// 转换参数类型
-		params := make(map[string]string)
-		for k, v := range textParams {
-			params[k] = v
-		}
-
-		// 构建查询字符串
-		values := url.Values{}
-		for k, v := range params {
-			if k != "" && v != "" {
-				values.Add(k, v)
-			}
-		}
-
-		// 创建请求
-		req, err := http.NewRequest(MethodPost, urlStr, strings.NewReader(values.Encode()))
-		if err != nil {
-			return "", err
-		}
-
-		// 设置请求头
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset="+charset)
-		req.Header.Set("User-Agent", "iot-gateway-sdk-go")
-		req.Header.Set("Accept", "text/xml,text/javascript")
-
-		// 设置客户端超时
-		client := &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: TrustAllCerts,
-			},
-			Timeout: time.Duration(connectTimeout+readTimeout) * time.Millisecond,
-		}
-
-		// 发送请求
-		resp, err := client.Do(req)
-		if err != nil {
-			return "", err
-		}
-		defer resp.Body.Close()
-
-		// 检查响应状态
-		if resp.StatusCode >= 400 {
-			return "", fmt.Errorf("HTTP错误: %d %s", resp.StatusCode, resp.Status)
-		}
+// DoPostWithFile 执行单个带文件上传的HTTP POST请求，没有文件时以表单提交，文本参数按 cs 编码
+func DoPostWithFile(ctx context.Context, client *http.Client, urlStr string, textParams map[string]string, fileParams map[string]*FileItem, cs string, timeouts Timeouts) (*HTTPResponse, error) {
+	canonical, err := charset.Normalize(cs)
+	if err != nil {
+		return nil, err
+	}
 
-		// 读取响应内容
-		body, err := ioutil.ReadAll(resp.Body)
+	// 如果没有文件参数，执行普通表单请求
+	if len(fileParams) == 0 {
+		values, err := encodeValues(textParams, canonical)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-
-		return string(body), nil
+		contentType := "application/x-www-form-urlencoded;charset=" + canonical
+		return execute(ctx, client, MethodPost, urlStr, contentType, strings.NewReader(values.Encode()), nil, canonical, timeouts)
 	}
 
 	// 创建多部分表单
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
-	// 添加文本参数
+	// 添加文本参数，参数值按字符集编码
 	for key, val := range textParams {
-		if err := writer.WriteField(key, val); err != nil {
-			return "", err
+		encoded, err := charset.Encode(val, canonical)
+		if err != nil {
+			return nil, err
+		}
+		if err := writer.WriteField(key, string(encoded)); err != nil {
+			return nil, err
 		}
 	}
 
 	// 添加文件参数
 	for key, fileItem := range fileParams {
-		if fileItem != nil && len(fileItem.Content) > 0 {
-			part, err := writer.CreateFormFile(key, filepath.Base(fileItem.FileName))
-			if err != nil {
-				return "", err
-			}
-
-			if _, err = part.Write(fileItem.Content); err != nil {
-				return "", err
-			}
+		if fileItem == nil || len(fileItem.Content) == 0 {
+			continue
+		}
+		part, err := createFilePart(writer, key, fileItem)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write(fileItem.Content); err != nil {
+			return nil, err
 		}
 	}
 
-	// 关闭writer
 	if err := writer.Close(); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return execute(ctx, client, MethodPost, urlStr, writer.FormDataContentType(), body, nil, canonical, timeouts)
+}
+
+// createFilePart 创建文件表单项，未指定MIME类型时使用 application/octet-stream
+func createFilePart(writer *multipart.Writer, key string, fileItem *FileItem) (io.Writer, error) {
+	mimeType := fileItem.MimeType
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
 	}
 
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+		"name":     key,
+		"filename": filepath.Base(fileItem.FileName),
+	}))
+	h.Set("Content-Type", mimeType)
+	return writer.CreatePart(h)
+}
+
+// encodeValues 将非空参数按字符集编码为 url.Values
+func encodeValues(params map[string]string, cs string) (url.Values, error) {
+	values := url.Values{}
+	for k, v := range params {
+		if k == "" || v == "" {
+			continue
+		}
+		encoded, err := charset.Encode(v, cs)
+		if err != nil {
+			return nil, err
+		}
+		values.Add(k, string(encoded))
+	}
+	return values, nil
+}
+
+// execute 在分阶段超时控制下执行单个HTTP请求，响应体按 Content-Type 中的字符集解码，未声明时使用 fallback
+func execute(ctx context.Context, client *http.Client, method, urlStr, contentType string, content io.Reader, header http.Header, fallback string, timeouts Timeouts) (*HTTPResponse, error) {
+	// 分阶段超时控制
+	a := startAttempt(ctx, timeouts)
+	defer a.finish()
+
 	// 创建请求
-	req, err := http.NewRequest(MethodPost, urlStr, body)
+	req, err := http.NewRequestWithContext(a.ctx, method, urlStr, content)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// 设置请求头
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 	req.Header.Set("User-Agent", "iot-gateway-sdk-go")
 	req.Header.Set("Accept", "text/xml,text/javascript")
-
-	// 设置客户端超时
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: TrustAllCerts,
-		},
-		Timeout: time.Duration(connectTimeout+readTimeout) * time.Millisecond,
+	for key, values := range header {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
 	}
 
 	// 发送请求
-	resp, err := client.Do(req)
+	resp, err := resolveClient(client).Do(req)
 	if err != nil {
-		return "", err
+		return nil, a.classify(err)
 	}
 	defer resp.Body.Close()
 
-	// 检查响应状态
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("HTTP错误: %d %s", resp.StatusCode, resp.Status)
+	// 处理gzip压缩
+	var reader io.ReadCloser = a.wrapBody(resp.Body)
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, a.classify(err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
 	}
 
 	// 读取响应内容
-	respBody, err := ioutil.ReadAll(resp.Body)
+	body, err := ioutil.ReadAll(reader)
 	if err != nil {
-		return "", err
+		return nil, a.classify(err)
+	}
+
+	// 检查响应状态，错误响应按字符集解码失败时保留原始内容
+	if resp.StatusCode >= 400 {
+		text, decodeErr := decodeBody(body, resp.Header.Get("Content-Type"), fallback)
+		if decodeErr != nil {
+			text = string(body)
+		}
+		return nil, &HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header,
+			Body:       text,
+		}
+	}
+
+	// 按 Content-Type 中的字符集解码
+	text, err := decodeBody(body, resp.Header.Get("Content-Type"), fallback)
+	if err != nil {
+		return nil, err
 	}
 
-	return string(respBody), nil
+	return &HTTPResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       text,
+	}, nil
 }
 
-// FileItem 文件项目
+// decodeBody 按 Content-Type 中的字符集将响应体解码为UTF-8，未声明字符集时使用 fallback（为空时视为UTF-8）
+func decodeBody(body []byte, contentType, fallback string) (string, error) {
+	cs := charset.FromContentType(contentType)
+	if cs == "" {
+		cs = fallback
+	}
+	return charset.Decode(body, cs)
+}
+
+// FileItem 上传的文件
 type FileItem struct {
 	FileName string
 	Content  []byte
 	MimeType string
 }
+
+// GetFileName 获取文件名
+func (f *FileItem) GetFileName() string {
+	return f.FileName
+}
+
+// GetContent 获取文件内容
+func (f *FileItem) GetContent() []byte {
+	return f.Content
+}
+
+// GetMimeType 获取MIME类型
+func (f *FileItem) GetMimeType() string {
+	return f.MimeType
+}
@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zhoudm1743/unicom-gw/api/internal/charset"
+)
+
+// serverCertPEM 返回测试TLS服务器证书的PEM内容
+func serverCertPEM(srv *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+}
+
+// clientFor 按TLS选项构建HTTP客户端
+func clientFor(t *testing.T, opts TLSOptions) *http.Client {
+	t.Helper()
+	tlsConfig, err := BuildTLSConfig(opts)
+	if err != nil {
+		t.Fatalf("BuildTLSConfig() error = %v", err)
+	}
+	cfg := DefaultTransportConfig()
+	cfg.TLSClientConfig = tlsConfig
+	return NewHTTPClient(NewTransport(cfg))
+}
+
+func TestHelpersUseClientTLSSettings(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	helpers := map[string]func(client *http.Client) (*HTTPResponse, error){
+		"DoGet": func(client *http.Client) (*HTTPResponse, error) {
+			return DoGet(context.Background(), client, srv.URL, map[string]string{"a": "1"}, Timeouts{})
+		},
+		"DoPostWithFile": func(client *http.Client) (*HTTPResponse, error) {
+			files := map[string]*FileItem{"f": {FileName: "a.txt", Content: []byte("x")}}
+			return DoPostWithFile(context.Background(), client, srv.URL, nil, files, DefaultCharset, Timeouts{})
+		},
+		"DoPostWithFile表单": func(client *http.Client) (*HTTPResponse, error) {
+			return DoPostWithFile(context.Background(), client, srv.URL, map[string]string{"a": "1"}, nil, DefaultCharset, Timeouts{})
+		},
+	}
+
+	for name, do := range helpers {
+		t.Run(name, func(t *testing.T) {
+			// 默认客户端校验证书，拒绝自签名证书
+			_, err := do(nil)
+			var unknownAuthority x509.UnknownAuthorityError
+			if !errors.As(err, &unknownAuthority) {
+				t.Errorf("默认客户端 error = %v, want x509.UnknownAuthorityError", err)
+			}
+
+			// 信任自定义根证书后可以访问
+			resp, err := do(clientFor(t, TLSOptions{RootCAPEM: serverCertPEM(srv)}))
+			if err != nil || resp.Body != "ok" {
+				t.Errorf("自定义根证书 resp = %v, error = %v", resp, err)
+			}
+		})
+	}
+}
+
+func TestDoPostWithFileSendsMultipart(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("无法解析表单: %v", err)
+		}
+		file, header, err := r.FormFile("upload")
+		if err != nil {
+			t.Fatalf("缺少文件: %v", err)
+		}
+		content, _ := ioutil.ReadAll(file)
+		if r.FormValue("name") != "value" || string(content) != "hello" || header.Filename != "a.txt" ||
+			header.Header.Get("Content-Type") != "text/plain" {
+			t.Errorf("表单内容不正确: name=%q content=%q file=%q type=%q",
+				r.FormValue("name"), content, header.Filename, header.Header.Get("Content-Type"))
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	files := map[string]*FileItem{"upload": {FileName: "dir/a.txt", Content: []byte("hello"), MimeType: "text/plain"}}
+	resp, err := DoPostWithFile(context.Background(), nil, srv.URL, map[string]string{"name": "value"}, files, DefaultCharset, Timeouts{})
+	if err != nil || !strings.Contains(resp.Body, "ok") {
+		t.Fatalf("DoPostWithFile() = %v, %v", resp, err)
+	}
+}
+
+func TestHelpersReturnHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := DoGet(context.Background(), nil, srv.URL, nil, Timeouts{})
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("DoGet() error = %v, want HTTPError 502", err)
+	}
+}
+
+func TestDoGetWithCharset(t *testing.T) {
+	var rawQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		// 未声明字符集，按请求字符集解码
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte{0xb3, 0xc9, 0xb9, 0xa6}) // GBK "成功"
+	}))
+	defer srv.Close()
+
+	resp, err := DoGetWithCharset(context.Background(), nil, srv.URL, map[string]string{"name": "中文"}, "gbk", Timeouts{})
+	if err != nil {
+		t.Fatalf("DoGetWithCharset() error = %v", err)
+	}
+	if rawQuery != "name=%D6%D0%CE%C4" {
+		t.Errorf("查询参数 = %q, want GBK编码 name=%%D6%%D0%%CE%%C4", rawQuery)
+	}
+	if resp.Body != "成功" {
+		t.Errorf("响应 = %q, want 成功", resp.Body)
+	}
+}
+
+func TestUnsupportedCharsetFailsBeforeSending(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("不支持的字符集不应发出请求")
+	}))
+	defer srv.Close()
+
+	var csErr *charset.UnsupportedCharsetError
+	if _, err := DoGetWithCharset(context.Background(), nil, srv.URL, nil, "latin1", Timeouts{}); !errors.As(err, &csErr) {
+		t.Errorf("DoGetWithCharset() error = %v, want UnsupportedCharsetError", err)
+	}
+	if _, err := DoPostWithFile(context.Background(), nil, srv.URL, nil, nil, "big5", Timeouts{}); !errors.As(err, &csErr) {
+		t.Errorf("DoPostWithFile() error = %v, want UnsupportedCharsetError", err)
+	}
+}
+
+func TestBuildGetURL(t *testing.T) {
+	tests := []struct {
+		url    string
+		params map[string]string
+		want   string
+	}{
+		{"http://h/p", nil, "http://h/p"},
+		{"http://h/p", map[string]string{"a": "1", "empty": ""}, "http://h/p?a=1"},
+		{"http://h/p?x=1", map[string]string{"a": "1"}, "http://h/p?x=1&a=1"},
+		{"http://h/p?", map[string]string{"a": "1"}, "http://h/p?a=1"},
+		{"http://h/p", map[string]string{"a": "中"}, "http://h/p?a=%E4%B8%AD"},
+	}
+
+	for _, tt := range tests {
+		got, err := BuildGetURL(tt.url, tt.params, DefaultCharset)
+		if err != nil || got != tt.want {
+			t.Errorf("BuildGetURL(%q, %v) = %q, %v, want %q", tt.url, tt.params, got, err, tt.want)
+		}
+	}
+}
@@ -1,10 +1,17 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
+	"github.com/zhoudm1743/unicom-gw/api/internal/charset"
 	"github.com/zhoudm1743/unicom-gw/api/internal/utils"
+	"github.com/zhoudm1743/unicom-gw/api/sign"
 )
 
 // IoTGatewayClient 定义IoT网关客户端接口
@@ -12,6 +19,9 @@ type IoTGatewayClient interface {
 	// Execute 执行API请求
 	Execute(request IoTGatewayRequest) (IoTGatewayResponse, error)
 
+	// ExecuteContext 执行API请求，ctx 控制取消与全部重试的总截止时间
+	ExecuteContext(ctx context.Context, request IoTGatewayRequest) (IoTGatewayResponse, error)
+
 	// GetServerURL 获取服务器URL
 	GetServerURL() string
 
@@ -21,10 +31,10 @@ type IoTGatewayClient interface {
 	// GetAppSecret 获取应用密钥
 	GetAppSecret() string
 
-	// GetConnectTimeout 获取连接超时时间
+	// GetConnectTimeout 获取连接超时时间，只约束建立连接与TLS握手
 	GetConnectTimeout() int
 
-	// GetReadTimeout 获取读取超时时间
+	// GetReadTimeout 获取读取超时时间，约束等待响应首字节与读取响应体
 	GetReadTimeout() int
 
 	// SetConnectTimeout 设置连接超时时间
@@ -42,7 +52,7 @@ type IoTGatewayClient interface {
 	// GetOpenID 获取openID
 	GetOpenID() string
 
-	// SetOpenID 设置openID
+	// SetOpenID 设置openID，发送请求时自动注入到业务数据
 	SetOpenID(openID string)
 }
 
@@ -54,7 +64,26 @@ type DefaultIoTGatewayClient struct {
 	OpenID         string
 	ConnectTimeout int
 	ReadTimeout    int
+	AttemptTimeout int
 	RetryCount     int
+
+	mu                  sync.Mutex
+	httpClient          *http.Client      // 调用方提供的HTTP客户端
+	transport           http.RoundTripper // 调用方提供的传输层
+	sharedClient        *http.Client      // 按连接池配置构建的共享客户端
+	maxIdleConnsPerHost int
+	idleConnTimeout     int
+	enableHTTP2         bool
+	tlsOptions          utils.TLSOptions
+	retryPolicy         RetryPolicy
+	retryCallback       RetryCallback
+	logger              Logger
+	interceptors        []Interceptor
+	transIDGenerator    TransIDGenerator
+	clock               Clock
+	skew                *clockSkew
+	signer              sign.Signer
+	charset             string
 }
 
 // NewIoTGatewayClient 创建一个新的IoT网关客户端
@@ -66,20 +95,50 @@ func NewIoTGatewayClient(serverURL, appID, appSecret, openID string) *DefaultIoT
 		OpenID:         openID,
 		ConnectTimeout: 2000,  // 默认连接超时2秒
 		ReadTimeout:    30000, // 默认读取超时30秒
+		AttemptTimeout: 0,     // 默认不限制单次尝试总时长
 		RetryCount:     0,     // 默认不重试
+
+		maxIdleConnsPerHost: utils.DefaultMaxIdleConnsPerHost,
+		idleConnTimeout:     int(utils.DefaultIdleConnTimeout / time.Millisecond),
+		enableHTTP2:         true,
+		retryPolicy:         NewExponentialBackoffRetryPolicy(),
+		logger:              NewNopLogger(),
+		transIDGenerator:    NewDefaultTransIDGenerator(),
+		clock:               SystemClock(),
+		skew:                newClockSkew(),
+		signer:              sign.Default(),
+		charset:             CHARSET_UTF8,
 	}
 }
 
 // Execute 执行API请求
 func (c *DefaultIoTGatewayClient) Execute(request IoTGatewayRequest) (IoTGatewayResponse, error) {
+	return c.ExecuteContext(context.Background(), request)
+}
+
+// ExecuteContext 执行API请求，ctx 取消或超时后立即停止后续重试
+func (c *DefaultIoTGatewayClient) ExecuteContext(ctx context.Context, request IoTGatewayRequest) (IoTGatewayResponse, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// 客户端参数检查，校验失败时不发起网络请求
+	if err := checkRequest(request, c.now()); err != nil {
+		return nil, err
+	}
+
 	// 执行POST请求
-	respMsg, err := c.doPost(request)
+	respMsg, err := c.doPost(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
+	// 空响应无法解析出信封，与格式错误的响应同样返回解码错误
 	if respMsg == "" {
-		return nil, nil
+		return nil, &ApiException{
+			ErrMsg:  "网关返回了空响应",
+			ErrCode: ERR_CODE_DECODE,
+		}
 	}
 
 	// 根据内容类型选择解析器
@@ -87,56 +146,338 @@ func (c *DefaultIoTGatewayClient) Execute(request IoTGatewayRequest) (IoTGateway
 	responseClass := request.GetResponseClass()
 
 	// 解析响应
-	var response IoTGatewayResponse
 	if contentType != "" && contentType == "text/xml" {
 		// XML解析（实际项目中可能需要实现）
 		return nil, fmt.Errorf("XML解析尚未实现")
-	} else {
-		// JSON解析
-		err = json.Unmarshal([]byte(respMsg), responseClass)
-		if err != nil {
-			responseClass.SetSuccess(false)
+	}
+
+	return decodeResponse(respMsg, responseClass)
+}
+
+// ExecuteInto 执行API请求，并将响应中的 data 解码到 out
+//
+// out 可以是结构体、切片或标量的指针，数字按 json.Number 语义解码以保留精度；
+// 传入 *json.RawMessage 时直接获得 data 的原始JSON。
+func (c *DefaultIoTGatewayClient) ExecuteInto(ctx context.Context, request IoTGatewayRequest, out interface{}) (IoTGatewayResponse, error) {
+	response, err := c.ExecuteContext(ctx, request)
+	if err != nil || response == nil {
+		return response, err
+	}
+
+	env, err := DecodeEnvelope(response.GetBody())
+	if err != nil {
+		return response, &ApiException{
+			ErrMsg:  "解析网关响应失败",
+			ErrCode: ERR_CODE_DECODE,
+			Cause:   err,
+			Body:    response.GetBody(),
+		}
+	}
+
+	if err := DecodeData(env.Data, out); err != nil {
+		return response, &ApiException{
+			ErrMsg:  "解析业务数据失败",
+			ErrCode: ERR_CODE_DECODE,
+			Cause:   err,
+			Body:    response.GetBody(),
+		}
+	}
+	return response, nil
+}
+
+// envelopeReceiver 可接收网关信封的响应，嵌入 BaseIoTGatewayResponse 的类型均已实现
+type envelopeReceiver interface {
+	SetEnvelope(env *Envelope)
+}
+
+// decodeResponse 解析网关信封并填充响应，JSON格式错误时返回附带原始响应体的 ApiException
+func decodeResponse(body string, response IoTGatewayResponse) (IoTGatewayResponse, error) {
+	env, err := DecodeEnvelope(body)
+	if err != nil {
+		return nil, &ApiException{
+			ErrMsg:  "解析网关响应失败",
+			ErrCode: ERR_CODE_DECODE,
+			Cause:   err,
+			Body:    body,
+		}
+	}
+
+	// 解析具体响应类型的字段
+	if err := json.Unmarshal([]byte(body), response); err != nil {
+		return nil, &ApiException{
+			ErrMsg:  "解析网关响应失败",
+			ErrCode: ERR_CODE_DECODE,
+			Cause:   err,
+			Body:    body,
 		}
-		response = responseClass
 	}
 
+	// 填充信封字段
+	if receiver, ok := response.(envelopeReceiver); ok {
+		receiver.SetEnvelope(env)
+	} else {
+		response.SetStatus(env.Status)
+		response.SetMessage(env.Message)
+		response.SetSuccess(env.IsSuccess())
+	}
+	response.SetBody(body)
+
 	return response, nil
 }
 
+// checkRequest 执行请求的 Check（实现 ClockedChecker 的请求以 now 调用 CheckAt），并将校验失败统一包装为 ApiRuleException
+func checkRequest(request IoTGatewayRequest, now time.Time) error {
+	if request == nil {
+		return NewApiRuleException("请求不能为空", ERR_CODE_INVALID, nil)
+	}
+
+	var err error
+	if checker, ok := request.(ClockedChecker); ok {
+		err = checker.CheckAt(now)
+	} else {
+		err = request.Check()
+	}
+	if err == nil {
+		return nil
+	}
+
+	var ruleErr *ApiRuleException
+	if errors.As(err, &ruleErr) {
+		return err
+	}
+	return NewApiRuleException("请求参数校验失败", ERR_CODE_INVALID, err)
+}
+
 // doPost 执行POST请求
-func (c *DefaultIoTGatewayClient) doPost(request IoTGatewayRequest) (string, error) {
-	// 构建请求参数
+func (c *DefaultIoTGatewayClient) doPost(ctx context.Context, request IoTGatewayRequest) (string, error) {
+	// 构建应用参数，请求通过 SetTransId 指定交易ID时沿用
+	params, err := c.appParams(c.transIDFor(request))
+	if err != nil {
+		return "", err
+	}
+
+	// 获取请求参数，并补充 openId、messageId、version 等默认字段
+	requestParams := applyDataDefaults(request, request.GetParams(), c.OpenID, transIDOf(params))
+	params["data"] = requestParams
+
+	// 请求发送前的处理
+	request.ExecProcessBeforeReqSend([]interface{}{params})
+
+	// 获取HTTP客户端
+	httpClient, err := c.getHTTPClient()
+	if err != nil {
+		return "", &ApiException{
+			ErrMsg: "构建HTTP客户端失败",
+			Cause:  err,
+		}
+	}
+
+	call := &Call{
+		Request: request,
+		ApiName: request.GetApiName(),
+		ApiVer:  request.GetApiVer(),
+		TransId: transIDOf(params),
+		URL:     utils.BuildPostURL(c.ServerURL, request.GetApiName(), request.GetApiVer()),
+		Params:  params,
+		Body:    request.GetReqText(),
+		Header:  http.Header{},
+
+		Idempotent: isIdempotent(request),
+		resign:     c.resign,
+	}
+
+	// 发送请求
+	result, err := c.buildHandler(httpClient)(ctx, call)
+	if err != nil {
+		return "", wrapContextError(err)
+	}
+	if result == nil {
+		return "", nil
+	}
+	return result.Body, nil
+}
+
+// transIDFor 获取请求使用的交易ID，调用方未指定时生成新ID
+func (c *DefaultIoTGatewayClient) transIDFor(request IoTGatewayRequest) string {
+	if transID := request.GetTransId(); transID != "" {
+		return transID
+	}
+	return c.nextTransID()
+}
+
+// appParams 构建应用参数（app_id、trans_id、timestamp 与签名），令牌时间戳使用补偿时钟偏差后的时间
+func (c *DefaultIoTGatewayClient) appParams(transID string) (map[string]interface{}, error) {
 	params := map[string]interface{}{
 		utils.AppIDKey:     c.AppID,
 		utils.AppSecretKey: c.AppSecret,
+		utils.TransIDKey:   transID,
 	}
 
-	// 构建应用参数
-	err := utils.BuildAppParams(params)
+	err := utils.BuildAppParams(params, c.now(), sign.WithCharset(c.GetSigner(), c.GetCharset()))
 	if err != nil {
-		return "", &ApiException{
+		return nil, &ApiException{
 			ErrMsg: "构建应用参数失败",
 			Cause:  err,
 		}
 	}
+	return params, nil
+}
 
-	// 获取请求参数
-	requestParams := request.GetParams()
-	params["data"] = requestParams
+// resign 重试前重新签名：沿用首次发送的交易ID与 messageId，只刷新时间戳与令牌，
+// 再执行请求发送前的处理重新生成请求报文
+func (c *DefaultIoTGatewayClient) resign(call *Call) error {
+	params, err := c.appParams(call.TransId)
+	if err != nil {
+		return err
+	}
 
-	// 请求发送前的处理
-	request.ExecProcessBeforeReqSend([]interface{}{params})
+	for _, key := range []string{sign.KeyToken, sign.KeySign, sign.KeySignMethod} {
+		delete(call.Params, key)
+	}
+	for key, value := range params {
+		call.Params[key] = value
+	}
 
-	// 发送请求
-	return utils.DoPost(
-		c.ServerURL,
-		request.GetApiName(),
-		request.GetApiVer(),
-		request.GetReqText(),
-		c.ConnectTimeout,
-		c.ReadTimeout,
-		c.RetryCount,
+	call.Request.ExecProcessBeforeReqSend([]interface{}{call.Params})
+	call.Body = call.Request.GetReqText()
+	return nil
+}
+
+// buildHandler 构建拦截器链：调用方拦截器 -> 重试 -> 日志 -> 时钟偏差学习 -> HTTP传输
+func (c *DefaultIoTGatewayClient) buildHandler(httpClient *http.Client) Handler {
+	skew := c.clockSkew()
+
+	c.mu.Lock()
+	interceptors := make([]Interceptor, 0, len(c.interceptors)+3)
+	interceptors = append(interceptors, c.interceptors...)
+	interceptors = append(interceptors,
+		RetryInterceptor(c.getRetryPolicy(), c.RetryCount, retryLogCallback(c.logger, c.retryCallback)),
+		LoggingInterceptor(c.logger),
 	)
+	if skew.isEnabled() {
+		interceptors = append(interceptors, skew.interceptor(c.getClock()))
+	}
+	c.mu.Unlock()
+
+	return Chain(c.transportHandler(httpClient), interceptors...)
+}
+
+// transportHandler 通过HTTP发送调用
+func (c *DefaultIoTGatewayClient) transportHandler(httpClient *http.Client) Handler {
+	timeouts := c.timeouts()
+	cs := c.GetCharset()
+	return func(ctx context.Context, call *Call) (*CallResult, error) {
+		content, err := charset.Encode(call.Body, cs)
+		if err != nil {
+			return nil, &ApiException{
+				ErrMsg:  "请求报文字符集编码失败",
+				ErrCode: ERR_CODE_CHARSET,
+				Cause:   err,
+			}
+		}
+
+		resp, err := utils.ExecutePost(
+			ctx,
+			httpClient,
+			call.URL,
+			"application/json;charset="+cs,
+			content,
+			call.Header,
+			timeouts,
+		)
+		if err != nil {
+			var charsetErr *UnsupportedCharsetError
+			if errors.As(err, &charsetErr) {
+				return nil, &ApiException{
+					ErrMsg:  "响应字符集不受支持",
+					ErrCode: ERR_CODE_CHARSET,
+					Cause:   err,
+				}
+			}
+			return nil, err
+		}
+		return &CallResult{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       resp.Body,
+		}, nil
+	}
+}
+
+// getHTTPClient 获取发送请求使用的HTTP客户端，默认在客户端生命周期内共享同一个连接池
+func (c *DefaultIoTGatewayClient) getHTTPClient() (*http.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.httpClient != nil {
+		return c.httpClient, nil
+	}
+
+	if c.sharedClient == nil {
+		transport := c.transport
+		if transport == nil {
+			tlsConfig, err := utils.BuildTLSConfig(c.tlsOptions)
+			if err != nil {
+				return nil, err
+			}
+			cfg := utils.DefaultTransportConfig()
+			cfg.MaxIdleConnsPerHost = c.maxIdleConnsPerHost
+			cfg.IdleConnTimeout = time.Duration(c.idleConnTimeout) * time.Millisecond
+			cfg.EnableHTTP2 = c.enableHTTP2
+			cfg.TLSClientConfig = tlsConfig
+			transport = utils.NewTransport(cfg)
+		}
+		c.sharedClient = utils.NewHTTPClient(transport)
+	}
+	return c.sharedClient, nil
+}
+
+// resetSharedClient 连接池配置变更后丢弃已构建的共享客户端，下次请求时重建
+func (c *DefaultIoTGatewayClient) resetSharedClient() {
+	if c.sharedClient == nil {
+		return
+	}
+	if t, ok := c.sharedClient.Transport.(*http.Transport); ok && c.transport == nil {
+		t.CloseIdleConnections()
+	}
+	c.sharedClient = nil
+}
+
+// timeouts 将毫秒配置转换为单次尝试的超时配置
+func (c *DefaultIoTGatewayClient) timeouts() utils.Timeouts {
+	return utils.Timeouts{
+		Connect: time.Duration(c.ConnectTimeout) * time.Millisecond,
+		Read:    time.Duration(c.ReadTimeout) * time.Millisecond,
+		Attempt: time.Duration(c.AttemptTimeout) * time.Millisecond,
+	}
+}
+
+// wrapContextError 将上下文取消、超时错误包装为 ApiException，保留原因供 errors.Is 判断
+func wrapContextError(err error) error {
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return &ApiException{
+			ErrMsg:  fmt.Sprintf("请求在%s阶段超时", timeoutErr.Phase),
+			ErrCode: ERR_CODE_TIMEOUT,
+			Cause:   err,
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return &ApiException{
+			ErrMsg:  "请求已取消",
+			ErrCode: ERR_CODE_CANCELED,
+			Cause:   err,
+		}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &ApiException{
+			ErrMsg:  "请求超过截止时间",
+			ErrCode: ERR_CODE_TIMEOUT,
+			Cause:   err,
+		}
+	}
+	return err
 }
 
 // GetServerURL 获取服务器URL
@@ -174,6 +515,90 @@ func (c *DefaultIoTGatewayClient) SetReadTimeout(timeout int) {
 	c.ReadTimeout = timeout
 }
 
+// GetAttemptTimeout 获取单次尝试的总超时时间
+func (c *DefaultIoTGatewayClient) GetAttemptTimeout() int {
+	return c.AttemptTimeout
+}
+
+// SetAttemptTimeout 设置单次尝试的总超时时间（单位：毫秒），0表示不限制
+func (c *DefaultIoTGatewayClient) SetAttemptTimeout(timeout int) {
+	c.AttemptTimeout = timeout
+}
+
+// getRetryPolicy 获取重试策略，未设置时使用默认的指数退避策略
+func (c *DefaultIoTGatewayClient) getRetryPolicy() RetryPolicy {
+	if c.retryPolicy == nil {
+		c.retryPolicy = NewExponentialBackoffRetryPolicy()
+	}
+	return c.retryPolicy
+}
+
+// GetRetryPolicy 获取重试策略
+func (c *DefaultIoTGatewayClient) GetRetryPolicy() RetryPolicy {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getRetryPolicy()
+}
+
+// SetRetryPolicy 设置重试策略，重试次数上限仍由 RetryCount 控制，为nil时恢复默认策略
+func (c *DefaultIoTGatewayClient) SetRetryPolicy(policy RetryPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retryPolicy = policy
+}
+
+// GetRetryCallback 获取每次尝试结束后的回调
+func (c *DefaultIoTGatewayClient) GetRetryCallback() RetryCallback {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.retryCallback
+}
+
+// SetRetryCallback 设置每次尝试结束后的回调，可用于记录重试日志
+func (c *DefaultIoTGatewayClient) SetRetryCallback(callback RetryCallback) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retryCallback = callback
+}
+
+// GetLogger 获取日志记录器
+func (c *DefaultIoTGatewayClient) GetLogger() Logger {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.logger
+}
+
+// SetLogger 设置日志记录器，为nil时不输出日志
+func (c *DefaultIoTGatewayClient) SetLogger(logger Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if logger == nil {
+		logger = NewNopLogger()
+	}
+	c.logger = logger
+}
+
+// GetInterceptors 获取调用方添加的拦截器
+func (c *DefaultIoTGatewayClient) GetInterceptors() []Interceptor {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interceptor(nil), c.interceptors...)
+}
+
+// SetInterceptors 设置拦截器，拦截器位于内置的重试与日志拦截器外层，按顺序执行
+func (c *DefaultIoTGatewayClient) SetInterceptors(interceptors ...Interceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interceptors = append([]Interceptor(nil), interceptors...)
+}
+
+// AddInterceptor 追加拦截器
+func (c *DefaultIoTGatewayClient) AddInterceptor(interceptors ...Interceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
 // GetRetryCount 获取重试次数
 func (c *DefaultIoTGatewayClient) GetRetryCount() int {
 	return c.RetryCount
@@ -189,7 +614,325 @@ func (c *DefaultIoTGatewayClient) GetOpenID() string {
 	return c.OpenID
 }
 
-// SetOpenID 设置openID
+// SetOpenID 设置openID，发送请求时自动注入到业务数据，请求参数中已有 openId 时以请求为准
 func (c *DefaultIoTGatewayClient) SetOpenID(openID string) {
 	c.OpenID = openID
 }
+
+// nextTransID 使用交易ID生成器生成交易ID，生成器支持时传入与令牌时间戳相同来源的当前时间
+func (c *DefaultIoTGatewayClient) nextTransID() string {
+	c.mu.Lock()
+	generator := c.transIDGenerator
+	c.mu.Unlock()
+	if generator == nil {
+		return utils.NewTransIDAt(c.now())
+	}
+	if clocked, ok := generator.(ClockedTransIDGenerator); ok {
+		return clocked.NextTransIDAt(c.now())
+	}
+	return generator.NextTransID()
+}
+
+// GetTransIDGenerator 获取交易ID生成器
+func (c *DefaultIoTGatewayClient) GetTransIDGenerator() TransIDGenerator {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.transIDGenerator
+}
+
+// SetTransIDGenerator 设置交易ID生成器，为nil时恢复默认生成器
+func (c *DefaultIoTGatewayClient) SetTransIDGenerator(generator TransIDGenerator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generator == nil {
+		generator = NewDefaultTransIDGenerator()
+	}
+	c.transIDGenerator = generator
+}
+
+// getClock 获取时钟，未设置时使用系统时钟
+func (c *DefaultIoTGatewayClient) getClock() Clock {
+	if c.clock == nil {
+		return SystemClock()
+	}
+	return c.clock
+}
+
+// clockSkew 获取时钟偏差补偿状态，零值客户端首次使用时创建
+func (c *DefaultIoTGatewayClient) clockSkew() *clockSkew {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.skew == nil {
+		c.skew = newClockSkew()
+	}
+	return c.skew
+}
+
+// now 获取构建令牌使用的当前时间，启用补偿时叠加已学习的偏差
+func (c *DefaultIoTGatewayClient) now() time.Time {
+	skew := c.clockSkew()
+	return skew.apply(c.GetClock().Now())
+}
+
+// GetClock 获取时钟
+func (c *DefaultIoTGatewayClient) GetClock() Clock {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getClock()
+}
+
+// SetClock 设置时钟，可在测试中固定令牌时间戳，为nil时恢复系统时钟
+func (c *DefaultIoTGatewayClient) SetClock(clock Clock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if clock == nil {
+		clock = SystemClock()
+	}
+	c.clock = clock
+}
+
+// SetClockSkewCompensation 设置是否启用网关时钟偏差补偿
+//
+// 启用后客户端从每次响应的 Date 头学习网关时间与本地时钟的差值，构建下一个令牌时叠加该差值。
+func (c *DefaultIoTGatewayClient) SetClockSkewCompensation(enabled bool) {
+	skew := c.clockSkew()
+	skew.mu.Lock()
+	defer skew.mu.Unlock()
+	skew.enabled = enabled
+	if !enabled {
+		skew.offset = 0
+	}
+}
+
+// IsClockSkewCompensationEnabled 是否启用网关时钟偏差补偿
+func (c *DefaultIoTGatewayClient) IsClockSkewCompensationEnabled() bool {
+	return c.clockSkew().isEnabled()
+}
+
+// SetClockSkewTolerance 设置从 Date 头学习偏差的容差，偏差在容差内时视为无偏差
+func (c *DefaultIoTGatewayClient) SetClockSkewTolerance(tolerance time.Duration) {
+	skew := c.clockSkew()
+	skew.mu.Lock()
+	defer skew.mu.Unlock()
+	skew.tolerance = tolerance
+}
+
+// SetTimestampRejectionCodes 设置表示时间戳被拒绝的网关状态码，收到这些状态码时无视容差直接采用 Date 头估算的偏差
+//
+// 拒绝响应本身不携带网关时间，响应没有 Date 头时无法估算偏差，已学习的偏差保持不变。
+func (c *DefaultIoTGatewayClient) SetTimestampRejectionCodes(codes ...string) {
+	skew := c.clockSkew()
+	skew.mu.Lock()
+	defer skew.mu.Unlock()
+	skew.rejectionCodes = make(map[string]bool, len(codes))
+	for _, code := range codes {
+		skew.rejectionCodes[code] = true
+	}
+}
+
+// GetClockSkew 获取已学习的网关时间与本地时钟的差值，正值表示网关时间较快
+func (c *DefaultIoTGatewayClient) GetClockSkew() time.Duration {
+	skew := c.clockSkew()
+	skew.mu.Lock()
+	defer skew.mu.Unlock()
+	return skew.offset
+}
+
+// SetClockSkew 直接设置网关时间与本地时钟的差值，如从持久化的值恢复；仅在启用补偿时生效
+func (c *DefaultIoTGatewayClient) SetClockSkew(offset time.Duration) {
+	skew := c.clockSkew()
+	skew.mu.Lock()
+	defer skew.mu.Unlock()
+	skew.offset = offset
+}
+
+// GetCharset 获取请求报文与令牌摘要使用的字符集
+func (c *DefaultIoTGatewayClient) GetCharset() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.charset == "" {
+		return CHARSET_UTF8
+	}
+	return c.charset
+}
+
+// SetCharset 设置请求报文与令牌摘要使用的字符集，如 CHARSET_GBK；不支持的字符集返回 UnsupportedCharsetError
+func (c *DefaultIoTGatewayClient) SetCharset(cs string) error {
+	canonical, err := charset.Normalize(cs)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.charset = canonical
+	return nil
+}
+
+// GetSigner 获取签名器
+func (c *DefaultIoTGatewayClient) GetSigner() sign.Signer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.signer == nil {
+		return sign.Default()
+	}
+	return c.signer
+}
+
+// SetSigner 设置签名器，为nil时恢复默认的SM3令牌签名
+func (c *DefaultIoTGatewayClient) SetSigner(signer sign.Signer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if signer == nil {
+		signer = sign.Default()
+	}
+	c.signer = signer
+}
+
+// SetSignMethod 按签名方法名称（SIGN_METHOD_*）设置签名器
+func (c *DefaultIoTGatewayClient) SetSignMethod(method string) error {
+	signer, err := sign.New(method)
+	if err != nil {
+		return err
+	}
+	c.SetSigner(signer)
+	return nil
+}
+
+// GetHTTPClient 获取调用方提供的HTTP客户端，未设置时返回nil
+func (c *DefaultIoTGatewayClient) GetHTTPClient() *http.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.httpClient
+}
+
+// SetHTTPClient 设置HTTP客户端，设置后连接池与TLS相关配置不再生效
+func (c *DefaultIoTGatewayClient) SetHTTPClient(client *http.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.httpClient = client
+}
+
+// GetTransport 获取调用方提供的传输层，未设置时返回nil
+func (c *DefaultIoTGatewayClient) GetTransport() http.RoundTripper {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.transport
+}
+
+// SetTransport 设置传输层，设置后连接池与TLS相关配置不再生效
+func (c *DefaultIoTGatewayClient) SetTransport(transport http.RoundTripper) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resetSharedClient()
+	c.transport = transport
+}
+
+// GetMaxIdleConnsPerHost 获取每个主机的最大空闲连接数
+func (c *DefaultIoTGatewayClient) GetMaxIdleConnsPerHost() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.maxIdleConnsPerHost
+}
+
+// SetMaxIdleConnsPerHost 设置每个主机的最大空闲连接数
+func (c *DefaultIoTGatewayClient) SetMaxIdleConnsPerHost(maxIdleConnsPerHost int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxIdleConnsPerHost = maxIdleConnsPerHost
+	c.resetSharedClient()
+}
+
+// GetIdleConnTimeout 获取空闲连接保留时长（单位：毫秒）
+func (c *DefaultIoTGatewayClient) GetIdleConnTimeout() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.idleConnTimeout
+}
+
+// SetIdleConnTimeout 设置空闲连接保留时长（单位：毫秒），0表示不限制
+func (c *DefaultIoTGatewayClient) SetIdleConnTimeout(timeout int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.idleConnTimeout = timeout
+	c.resetSharedClient()
+}
+
+// IsHTTP2Enabled 是否尝试使用HTTP/2
+func (c *DefaultIoTGatewayClient) IsHTTP2Enabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enableHTTP2
+}
+
+// SetHTTP2Enabled 设置是否尝试使用HTTP/2
+func (c *DefaultIoTGatewayClient) SetHTTP2Enabled(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enableHTTP2 = enabled
+	c.resetSharedClient()
+}
+
+// SetRootCAFile 设置自定义根证书文件（PEM格式），用于校验网关证书
+func (c *DefaultIoTGatewayClient) SetRootCAFile(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tlsOptions.RootCAFile = path
+	c.resetSharedClient()
+}
+
+// SetRootCAPEM 设置自定义根证书内容（PEM格式），用于校验网关证书
+func (c *DefaultIoTGatewayClient) SetRootCAPEM(pem []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tlsOptions.RootCAPEM = pem
+	c.resetSharedClient()
+}
+
+// SetClientCertFile 设置双向认证的客户端证书与私钥文件（PEM格式）
+func (c *DefaultIoTGatewayClient) SetClientCertFile(certFile, keyFile string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tlsOptions.ClientCertFile = certFile
+	c.tlsOptions.ClientKeyFile = keyFile
+	c.resetSharedClient()
+}
+
+// SetClientCertPEM 设置双向认证的客户端证书与私钥内容（PEM格式）
+func (c *DefaultIoTGatewayClient) SetClientCertPEM(certPEM, keyPEM []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tlsOptions.ClientCertPEM = certPEM
+	c.tlsOptions.ClientKeyPEM = keyPEM
+	c.resetSharedClient()
+}
+
+// GetMinTLSVersion 获取最低TLS版本，0表示使用默认值（TLS 1.2）
+func (c *DefaultIoTGatewayClient) GetMinTLSVersion() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tlsOptions.MinVersion
+}
+
+// SetMinTLSVersion 设置最低TLS版本，如 tls.VersionTLS13
+func (c *DefaultIoTGatewayClient) SetMinTLSVersion(version uint16) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tlsOptions.MinVersion = version
+	c.resetSharedClient()
+}
+
+// IsInsecureSkipVerifyForTesting 是否跳过证书校验
+func (c *DefaultIoTGatewayClient) IsInsecureSkipVerifyForTesting() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tlsOptions.InsecureSkipVerify
+}
+
+// SetInsecureSkipVerifyForTesting 跳过网关证书校验，仅可用于 gwtest.10646.cn 等测试环境
+func (c *DefaultIoTGatewayClient) SetInsecureSkipVerifyForTesting(insecure bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tlsOptions.InsecureSkipVerify = insecure
+	c.resetSharedClient()
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api/sign"
+)
+
+// testRequest 测试用的最小请求实现
+type testRequest struct {
+	BaseIoTGatewayRequest
+	params     map[string]interface{}
+	idempotent bool
+}
+
+func newTestRequest() *testRequest {
+	r := &testRequest{params: map[string]interface{}{}}
+	r.SetApiName("wsGetTerminalDetails/V1/1Main")
+	r.SetApiVer("V1.1")
+	return r
+}
+
+func (r *testRequest) GetParams() map[string]interface{} {
+	return r.params
+}
+
+func (r *testRequest) GetResponseClass() IoTGatewayResponse {
+	return &BaseIoTGatewayResponse{}
+}
+
+func (r *testRequest) IsIdempotent() bool {
+	return r.idempotent
+}
+
+func (r *testRequest) Check() error {
+	return nil
+}
+
+func (r *testRequest) ExecProcessBeforeReqSend(params []interface{}) {
+	if len(params) == 0 {
+		return
+	}
+	if data, err := json.Marshal(params[0]); err == nil {
+		r.SetReqText(string(data))
+	}
+}
+
+// sentParams 网关收到的一次请求
+type sentParams struct {
+	AppID     string                 `json:"app_id"`
+	Timestamp string                 `json:"timestamp"`
+	TransID   string                 `json:"trans_id"`
+	Token     string                 `json:"token"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+func TestRetryResignsEachAttempt(t *testing.T) {
+	gatewayAhead := time.Hour
+
+	var mu sync.Mutex
+	var sent []sentParams
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var p sentParams
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Errorf("无法解析请求报文: %v", err)
+		}
+
+		mu.Lock()
+		sent = append(sent, p)
+		first := len(sent) == 1
+		mu.Unlock()
+
+		w.Header().Set("Date", time.Now().Add(gatewayAhead).UTC().Format(http.TimeFormat))
+		if first {
+			w.Write([]byte(`{"status":"1003","message":"时间戳无效"}`))
+			return
+		}
+		w.Write([]byte(`{"status":"0000","message":"成功","data":{}}`))
+	}))
+	defer srv.Close()
+
+	policy := NewExponentialBackoffRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.RetryableCodes = []string{"1003"}
+
+	client := NewIoTGatewayClient(srv.URL, "app", "secret", "open")
+	client.SetRetryCount(1)
+	client.SetRetryPolicy(policy)
+	client.SetClockSkewCompensation(true)
+	client.SetTimestampRejectionCodes("1003")
+
+	resp, err := client.Execute(newTestRequest())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !resp.IsSuccess() {
+		t.Fatalf("重试后应成功，实际状态 %s", resp.GetStatus())
+	}
+	if len(sent) != 2 {
+		t.Fatalf("发送次数 = %d, want 2", len(sent))
+	}
+
+	first, second := sent[0], sent[1]
+	if first.TransID != second.TransID {
+		t.Errorf("重试的交易ID = %s, 应沿用首次发送的 %s", second.TransID, first.TransID)
+	}
+	if second.Data[DATA_MESSAGE_ID] != first.TransID {
+		t.Errorf("重试的 messageId = %v, 应沿用首次发送的 %s", second.Data[DATA_MESSAGE_ID], first.TransID)
+	}
+
+	loc := GatewayLocation()
+	t1, err1 := time.ParseInLocation("2006-01-02 15:04:05 000", first.Timestamp, loc)
+	t2, err2 := time.ParseInLocation("2006-01-02 15:04:05 000", second.Timestamp, loc)
+	if err1 != nil || err2 != nil {
+		t.Fatalf("无法解析时间戳: %v %v", err1, err2)
+	}
+	if d := t2.Sub(t1); d < gatewayAhead-5*time.Second || d > gatewayAhead+5*time.Second {
+		t.Errorf("重试的时间戳应叠加学习到的偏差，相差 %s", d)
+	}
+
+	for i, p := range sent {
+		params := map[string]interface{}{
+			sign.KeyAppID:     p.AppID,
+			sign.KeyTimestamp: p.Timestamp,
+			sign.KeyTransID:   p.TransID,
+			sign.KeyToken:     p.Token,
+		}
+		if err := sign.VerifyParams(sign.SM3TokenSigner{}, params, "secret"); err != nil {
+			t.Errorf("第%d次尝试的令牌校验失败: %v", i+1, err)
+		}
+	}
+}
+
+func TestRetryKeepsCallerTransID(t *testing.T) {
+	var mu sync.Mutex
+	var transIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var p sentParams
+		json.Unmarshal(body, &p)
+
+		mu.Lock()
+		transIDs = append(transIDs, p.TransID)
+		first := len(transIDs) == 1
+		mu.Unlock()
+
+		if first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"0000","message":"成功","data":{}}`))
+	}))
+	defer srv.Close()
+
+	client := NewIoTGatewayClient(srv.URL, "app", "secret", "open")
+	client.SetRetryCount(1)
+	client.SetRetryPolicy(&ExponentialBackoffRetryPolicy{BaseDelay: time.Millisecond, Multiplier: 1})
+
+	req := newTestRequest()
+	req.idempotent = true
+	req.SetTransId("20240101120000000000001")
+	if _, err := client.ExecuteContext(context.Background(), req); err != nil {
+		t.Fatalf("ExecuteContext() error = %v", err)
+	}
+
+	if len(transIDs) != 2 || transIDs[0] != transIDs[1] || transIDs[1] != "20240101120000000000001" {
+		t.Errorf("重试应沿用调用方指定的交易ID，实际 %v", transIDs)
+	}
+}
@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 // IoTGatewayRequest 定义IoT网关请求接口
 type IoTGatewayRequest interface {
 	// GetContentType 获取内容类型
@@ -33,13 +35,22 @@ type IoTGatewayRequest interface {
 	SetTransId(transId string)
 }
 
+// ClockedChecker 可选接口，校验规则依赖当前时间的请求实现后，客户端以自身时钟（含偏差补偿）的当前时间调用 CheckAt 代替 Check
+type ClockedChecker interface {
+	// CheckAt 以 now 作为当前时间进行客户端参数检查
+	CheckAt(now time.Time) error
+}
+
 // BaseIoTGatewayRequest IoT网关请求基础实现
 type BaseIoTGatewayRequest struct {
 	ApiName string
 	ApiVer  string
 	ApiType string
 	ReqText string
+	// TransId 调用方指定的交易ID，为空时客户端每次执行都生成新ID
 	TransId string
+	// LastTransId 最近一次执行实际发送的交易ID
+	LastTransId string
 }
 
 // GetContentType 获取内容类型
@@ -94,12 +105,22 @@ func (r *BaseIoTGatewayRequest) SetReqText(reqText string) {
 	r.ReqText = reqText
 }
 
-// GetTransId 获取交易ID
+// GetTransId 获取调用方指定的交易ID
 func (r *BaseIoTGatewayRequest) GetTransId() string {
 	return r.TransId
 }
 
-// SetTransId 设置交易ID
+// SetTransId 设置交易ID，客户端执行时原样使用；设为空串恢复每次自动生成
 func (r *BaseIoTGatewayRequest) SetTransId(transId string) {
 	r.TransId = transId
 }
+
+// GetLastTransId 获取最近一次执行实际发送的交易ID
+func (r *BaseIoTGatewayRequest) GetLastTransId() string {
+	return r.LastTransId
+}
+
+// SetLastTransId 记录实际发送的交易ID，由 ExecProcessBeforeReqSend 调用
+func (r *BaseIoTGatewayRequest) SetLastTransId(transId string) {
+	r.LastTransId = transId
+}
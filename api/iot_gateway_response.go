@@ -1,5 +1,7 @@
 package api
 
+import "encoding/json"
+
 // IoTGatewayResponse 定义IoT网关响应接口
 type IoTGatewayResponse interface {
 	// IsSuccess 请求是否成功
@@ -28,11 +30,46 @@ type IoTGatewayResponse interface {
 }
 
 // BaseIoTGatewayResponse IoT网关响应基础实现
+//
+// 信封字段由客户端解析网关响应后填充，Body 始终保存原始响应体。
 type BaseIoTGatewayResponse struct {
-	Status  string
-	Message string
-	Body    string
-	IsSucc  bool
+	Status  string                     `json:"-"`
+	Message string                     `json:"-"`
+	TransId string                     `json:"-"`
+	Body    string                     `json:"-"`
+	IsSucc  bool                       `json:"-"`
+	RawData json.RawMessage            `json:"-"`
+	Extra   map[string]json.RawMessage `json:"-"`
+}
+
+// SetEnvelope 使用解析后的信封填充状态码、消息、交易ID及其他字段，并按状态码设置是否成功
+func (r *BaseIoTGatewayResponse) SetEnvelope(env *Envelope) {
+	r.Status = env.Status
+	r.Message = env.Message
+	r.TransId = env.TransId
+	r.RawData = env.Data
+	r.Extra = env.Extra
+	r.IsSucc = env.IsSuccess()
+}
+
+// GetTransId 获取响应中的交易ID
+func (r *BaseIoTGatewayResponse) GetTransId() string {
+	return r.TransId
+}
+
+// GetRawData 获取业务数据的原始JSON
+func (r *BaseIoTGatewayResponse) GetRawData() json.RawMessage {
+	return r.RawData
+}
+
+// DecodeData 将业务数据解码到 out，数字按 json.Number 保留精度
+func (r *BaseIoTGatewayResponse) DecodeData(out interface{}) error {
+	return DecodeData(r.RawData, out)
+}
+
+// GetExtra 获取信封中除 status、message、trans_id、data 以外的字段
+func (r *BaseIoTGatewayResponse) GetExtra() map[string]json.RawMessage {
+	return r.Extra
 }
 
 // IsSuccess 请求是否成功
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// LogLevel 日志级别
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// String 返回日志级别名称
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+const (
+	// 日志字段
+	LOG_FIELD_TRANS_ID    = "trans_id"
+	LOG_FIELD_API_NAME    = "api_name"
+	LOG_FIELD_API_VER     = "api_ver"
+	LOG_FIELD_URL         = "url"
+	LOG_FIELD_ATTEMPT     = "attempt"
+	LOG_FIELD_LATENCY     = "latency"
+	LOG_FIELD_STATUS_CODE = "status_code"
+	LOG_FIELD_REQUEST     = "request"
+	LOG_FIELD_RESPONSE    = "response"
+	LOG_FIELD_ERROR       = "error"
+	LOG_FIELD_RETRY_DELAY = "retry_delay"
+
+	// 脱敏后的占位内容
+	REDACTED = "******"
+)
+
+// LogField 结构化日志字段
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+// Field 创建一个日志字段
+func Field(key string, value interface{}) LogField {
+	return LogField{Key: key, Value: value}
+}
+
+// Logger 结构化日志接口，客户端在调用前已对敏感字段脱敏
+type Logger interface {
+	// Log 记录一条日志
+	Log(level LogLevel, msg string, fields ...LogField)
+}
+
+// nopLogger 不输出任何日志
+type nopLogger struct{}
+
+// Log 实现Logger接口
+func (nopLogger) Log(LogLevel, string, ...LogField) {}
+
+// NewNopLogger 创建不输出任何日志的Logger，是客户端的默认值
+func NewNopLogger() Logger {
+	return nopLogger{}
+}
+
+// StdLogger 标准库 log 包的适配器
+type StdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+// NewStdLogger 创建标准库 log 包的适配器，低于 level 的日志不输出；logger 为nil时使用 log 包的默认Logger
+func NewStdLogger(logger *log.Logger, level LogLevel) *StdLogger {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &StdLogger{logger: logger, level: level}
+}
+
+// Log 实现Logger接口，以 key=value 形式输出字段
+func (l *StdLogger) Log(level LogLevel, msg string, fields ...LogField) {
+	if level < l.level {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(level.String())
+	sb.WriteString("] ")
+	sb.WriteString(msg)
+	for _, f := range fields {
+		sb.WriteString(" ")
+		sb.WriteString(f.Key)
+		sb.WriteString("=")
+		sb.WriteString(fmt.Sprint(f.Value))
+	}
+	l.logger.Print(sb.String())
+}
+
+// secretKeys 需要完全隐藏的字段（小写）
+var secretKeys = map[string]bool{
+	"token":       true,
+	"app_secrect": true,
+	"app_secret":  true,
+	"appsecret":   true,
+	"app_id":      true,
+	"appid":       true,
+	"openid":      true,
+	"open_id":     true,
+	"sign":        true,
+	"password":    true,
+	"secret":      true,
+}
+
+// maskedKeys 只保留末4位的字段（小写）
+var maskedKeys = map[string]bool{
+	"iccid":   true,
+	"iccids":  true,
+	"msisdn":  true,
+	"msisdns": true,
+	"imsi":    true,
+	"imsis":   true,
+	"imei":    true,
+}
+
+// RedactFields 对日志字段中的密钥与终端标识脱敏
+func RedactFields(fields []LogField) []LogField {
+	redacted := make([]LogField, len(fields))
+	for i, f := range fields {
+		redacted[i] = LogField{Key: f.Key, Value: redactValue(f.Key, f.Value)}
+		if s, ok := f.Value.(string); ok && (f.Key == LOG_FIELD_REQUEST || f.Key == LOG_FIELD_RESPONSE) {
+			redacted[i].Value = RedactJSON(s)
+		}
+	}
+	return redacted
+}
+
+// RedactJSON 对JSON报文中的密钥与终端标识脱敏，无法解析时整体隐藏
+func RedactJSON(body string) string {
+	if strings.TrimSpace(body) == "" {
+		return body
+	}
+
+	var v interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return REDACTED
+	}
+
+	data, err := json.Marshal(redactValue("", v))
+	if err != nil {
+		return REDACTED
+	}
+	return string(data)
+}
+
+// redactValue 按字段名递归脱敏
+func redactValue(key string, v interface{}) interface{} {
+	lowerKey := strings.ToLower(key)
+	if secretKeys[lowerKey] {
+		return REDACTED
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[k] = redactValue(k, item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = redactValue(key, item)
+		}
+		return out
+	case []string:
+		if !maskedKeys[lowerKey] {
+			return val
+		}
+		out := make([]string, len(val))
+		for i, item := range val {
+			out[i] = maskIdentifier(item)
+		}
+		return out
+	case string:
+		if maskedKeys[lowerKey] {
+			return maskIdentifier(val)
+		}
+		return val
+	case json.Number:
+		if maskedKeys[lowerKey] {
+			return maskIdentifier(val.String())
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+// maskIdentifier 只保留终端标识的末4位
+func maskIdentifier(s string) string {
+	if len(s) <= 4 {
+		return REDACTED
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
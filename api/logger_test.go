@@ -0,0 +1,140 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "隐藏密钥与应用标识",
+			body: `{"app_id":"app123","token":"abc","sign":"s","sign_method":"md5","app_secrect":"x","trans_id":"t1"}`,
+			want: `{"app_id":"******","app_secrect":"******","sign":"******","sign_method":"md5","token":"******","trans_id":"t1"}`,
+		},
+		{
+			name: "字段名不区分大小写",
+			body: `{"APP_SECRET":"x","AppId":"a","Password":"p"}`,
+			want: `{"APP_SECRET":"******","AppId":"******","Password":"******"}`,
+		},
+		{
+			name: "终端标识只保留末4位",
+			body: `{"iccid":"89860625680009634556","msisdn":"8613800138000","imsi":"460010123456789","imei":"356938035643809"}`,
+			want: `{"iccid":"****************4556","imei":"***********3809","imsi":"***********6789","msisdn":"*********8000"}`,
+		},
+		{
+			name: "嵌套data与数组",
+			body: `{"data":{"openId":"open1","iccids":["89860625680009634556","89860625680009634557"],"terminals":[{"iccid":"89860625680009634558","status":"ACTIVATED_NAME"}]}}`,
+			want: `{"data":{"iccids":["****************4556","****************4557"],"openId":"******","terminals":[{"iccid":"****************4558","status":"ACTIVATED_NAME"}]}}`,
+		},
+		{
+			name: "数字形式的终端标识",
+			body: `{"msisdn":8613800138000,"count":12}`,
+			want: `{"count":12,"msisdn":"*********8000"}`,
+		},
+		{
+			name: "过短的标识整体隐藏",
+			body: `{"imsi":"123"}`,
+			want: `{"imsi":"******"}`,
+		},
+		{
+			name: "无法解析的报文整体隐藏",
+			body: `app_id=app123&token=abc`,
+			want: REDACTED,
+		},
+		{
+			name: "空报文原样返回",
+			body: "  ",
+			want: "  ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactJSON(tt.body); got != tt.want {
+				t.Errorf("RedactJSON() = %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactFields(t *testing.T) {
+	fields := RedactFields([]LogField{
+		Field(LOG_FIELD_API_NAME, "wsGetTerminalDetails"),
+		Field("token", "abc"),
+		Field("iccid", "89860625680009634556"),
+		Field("iccids", []string{"89860625680009634556"}),
+		Field(LOG_FIELD_REQUEST, `{"app_id":"app123","data":{"iccid":"89860625680009634556"}}`),
+		Field(LOG_FIELD_RESPONSE, `not json`),
+		Field(LOG_FIELD_ATTEMPT, 2),
+	})
+
+	want := []string{
+		"wsGetTerminalDetails",
+		REDACTED,
+		"****************4556",
+		"[****************4556]",
+		`{"app_id":"******","data":{"iccid":"****************4556"}}`,
+		REDACTED,
+		"2",
+	}
+	for i, f := range fields {
+		if got := fmt.Sprint(f.Value); got != want[i] {
+			t.Errorf("%s = %s, want %s", f.Key, got, want[i])
+		}
+	}
+}
+
+// recordingLogger 记录全部日志内容
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Log(level LogLevel, msg string, fields ...LogField) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	line := msg
+	for _, f := range fields {
+		line += fmt.Sprintf(" %s=%v", f.Key, f.Value)
+	}
+	l.lines = append(l.lines, line)
+}
+
+func TestClientLogsAreRedacted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"0000","message":"成功","data":{"iccid":"89860625680009634556"}}`))
+	}))
+	defer srv.Close()
+
+	logger := &recordingLogger{}
+	client := NewIoTGatewayClient(srv.URL, "app-secret-id", "top-secret", "open-secret-id")
+	client.SetLogger(logger)
+
+	req := newTestRequest()
+	req.params["iccid"] = "89860625680009634556"
+	if _, err := client.Execute(req); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	all := strings.Join(logger.lines, "\n")
+	if all == "" {
+		t.Fatal("未记录日志")
+	}
+	for _, secret := range []string{"app-secret-id", "top-secret", "open-secret-id", "89860625680009634556"} {
+		if strings.Contains(all, secret) {
+			t.Errorf("日志泄露了 %s:\n%s", secret, all)
+		}
+	}
+	if !strings.Contains(all, "4556") {
+		t.Errorf("日志应保留ICCID末4位:\n%s", all)
+	}
+}
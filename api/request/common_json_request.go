@@ -2,16 +2,25 @@ package request
 
 import (
 	"encoding/json"
+	"regexp"
 
 	"github.com/zhoudm1743/unicom-gw/api"
 	"github.com/zhoudm1743/unicom-gw/api/internal/utils"
 	"github.com/zhoudm1743/unicom-gw/api/response"
 )
 
+// apiVerPattern API版本格式，如 V1.1、1.0
+var apiVerPattern = regexp.MustCompile(`^[Vv]?\d+(\.\d+)*$`)
+
 // CommonJsonRequest 通用JSON请求实现
 type CommonJsonRequest struct {
 	api.BaseIoTGatewayRequest
 	Params map[string]interface{}
+	// DisableDefaultData 为true时客户端不自动注入 openId、messageId、version
+	DisableDefaultData bool
+	// Idempotent 为true时重试策略可以重试已发出的请求（读取超时、连接中断、5xx），
+	// 查询类请求默认开启；发送短信、修改终端等请求重复执行会产生副作用，默认关闭
+	Idempotent bool
 }
 
 // NewCommonJsonRequest 创建一个新的通用JSON请求
@@ -31,6 +40,23 @@ func (r *CommonJsonRequest) SetParams(params map[string]interface{}) {
 	r.Params = params
 }
 
+// newQueryRequest 创建查询类请求，重复执行没有副作用
+func newQueryRequest() *CommonJsonRequest {
+	r := NewCommonJsonRequest()
+	r.Idempotent = true
+	return r
+}
+
+// IsIdempotent 请求是否可以安全地重复发送
+func (r *CommonJsonRequest) IsIdempotent() bool {
+	return r.Idempotent
+}
+
+// IsDefaultDataDisabled 是否关闭客户端注入的默认业务字段
+func (r *CommonJsonRequest) IsDefaultDataDisabled() bool {
+	return r.DisableDefaultData
+}
+
 // GetResponseClass 获取响应类型
 func (r *CommonJsonRequest) GetResponseClass() api.IoTGatewayResponse {
 	return &response.CommonJsonResponse{}
@@ -38,17 +64,42 @@ func (r *CommonJsonRequest) GetResponseClass() api.IoTGatewayResponse {
 
 // Check 客户端参数检查，减少服务端无效调用
 func (r *CommonJsonRequest) Check() error {
-	// 可以在这里实现参数验证逻辑
-	return nil
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	return ex.ErrOrNil()
+}
+
+// CheckBase 基础参数检查：API名称、版本及参数可序列化，校验错误追加到 ex
+//
+// params 为实际发送的业务参数，嵌入 CommonJsonRequest 并重写 GetParams 的请求应传入自身 GetParams 的结果。
+func (r *CommonJsonRequest) CheckBase(ex *api.ApiRuleException, params map[string]interface{}) {
+	if r.GetApiName() == "" {
+		ex.AddViolation("ApiName", "不能为空")
+	}
+
+	if r.GetApiVer() == "" {
+		ex.AddViolation("ApiVer", "不能为空")
+	} else if !apiVerPattern.MatchString(r.GetApiVer()) {
+		ex.AddViolation("ApiVer", "格式不正确，应类似 V1.1")
+	}
+
+	if _, err := json.Marshal(params); err != nil {
+		ex.AddViolation("Params", "无法序列化为JSON: "+err.Error())
+	}
+}
+
+// newRuleException 创建参数校验异常，用于收集字段级校验错误
+func newRuleException() *api.ApiRuleException {
+	return api.NewApiRuleException("请求参数校验失败", api.ERR_CODE_INVALID, nil)
 }
 
 // ExecProcessBeforeReqSend 请求发送前的处理
 func (r *CommonJsonRequest) ExecProcessBeforeReqSend(params []interface{}) {
 	if len(params) > 0 {
 		if mapParams, ok := params[0].(map[string]interface{}); ok {
-			// 设置交易ID
+			// 记录实际发送的交易ID，不覆盖调用方指定的 TransId
 			if transID, ok := mapParams[utils.TransIDKey].(string); ok {
-				r.SetTransId(transID)
+				r.SetLastTransId(transID)
 			}
 
 			// 将参数转换为JSON
@@ -0,0 +1,187 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// recordingServer 记录每次请求报文中的 trans_id 与 data.messageId
+type recordingServer struct {
+	*httptest.Server
+	mu         sync.Mutex
+	transIDs   []string
+	messageIDs []string
+}
+
+func newRecordingServer(t *testing.T) *recordingServer {
+	s := &recordingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var params struct {
+			TransID string                 `json:"trans_id"`
+			Data    map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &params); err != nil {
+			t.Errorf("无法解析请求报文: %v", err)
+		}
+		messageID, _ := params.Data[api.DATA_MESSAGE_ID].(string)
+
+		s.mu.Lock()
+		s.transIDs = append(s.transIDs, params.TransID)
+		s.messageIDs = append(s.messageIDs, messageID)
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		w.Write([]byte(`{"status":"0000","message":"成功","data":{}}`))
+	}))
+	return s
+}
+
+// gatewayCall 模拟网关收到的一次调用
+type gatewayCall struct {
+	Path string
+	Data map[string]interface{}
+}
+
+// scriptedServer 按调用内容返回业务数据的模拟网关，记录每次调用的路径与 data
+type scriptedServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	calls []gatewayCall
+}
+
+// newScriptedServer 创建模拟网关，reply 返回成功响应中 data 字段的JSON
+func newScriptedServer(t *testing.T, reply func(call gatewayCall) string) *scriptedServer {
+	s := &scriptedServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var params struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &params); err != nil {
+			t.Errorf("无法解析请求报文: %v", err)
+		}
+		call := gatewayCall{Path: r.URL.Path, Data: params.Data}
+
+		s.mu.Lock()
+		s.calls = append(s.calls, call)
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		w.Write([]byte(`{"status":"0000","message":"成功","data":` + reply(call) + `}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Calls 返回已收到的调用
+func (s *scriptedServer) Calls() []gatewayCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]gatewayCall(nil), s.calls...)
+}
+
+// client 创建访问该模拟网关的客户端
+func (s *scriptedServer) client() api.IoTGatewayClient {
+	return api.NewIoTGatewayClient(s.URL, "app", "secret", "open")
+}
+
+func newTestRequest() *CommonJsonRequest {
+	req := NewCommonJsonRequest()
+	req.SetApiName("wsGetTerminalDetails/V1/1Main")
+	req.SetApiVer("V1.1")
+	return req
+}
+
+func TestExecuteSameRequestGeneratesNewTransID(t *testing.T) {
+	srv := newRecordingServer(t)
+	defer srv.Close()
+
+	client := api.NewIoTGatewayClient(srv.URL, "app", "secret", "open")
+	req := newTestRequest()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Execute(req); err != nil {
+			t.Fatalf("第%d次执行失败: %v", i+1, err)
+		}
+		if got := req.GetLastTransId(); got != srv.transIDs[i] {
+			t.Errorf("GetLastTransId() = %q, 实际发送 %q", got, srv.transIDs[i])
+		}
+	}
+
+	if srv.transIDs[0] == srv.transIDs[1] {
+		t.Errorf("两次执行使用了相同的 trans_id %q", srv.transIDs[0])
+	}
+	if srv.messageIDs[0] == srv.messageIDs[1] {
+		t.Errorf("两次执行使用了相同的 messageId %q", srv.messageIDs[0])
+	}
+	if req.GetTransId() != "" {
+		t.Errorf("GetTransId() = %q, 生成的ID不应写回调用方字段", req.GetTransId())
+	}
+}
+
+func TestExecuteHonoursCallerTransID(t *testing.T) {
+	srv := newRecordingServer(t)
+	defer srv.Close()
+
+	client := api.NewIoTGatewayClient(srv.URL, "app", "secret", "open")
+	req := newTestRequest()
+	req.SetTransId("20240101120000000000001")
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Execute(req); err != nil {
+			t.Fatalf("第%d次执行失败: %v", i+1, err)
+		}
+	}
+
+	for i, id := range srv.transIDs {
+		if id != "20240101120000000000001" {
+			t.Errorf("第%d次发送的 trans_id = %q, 应沿用调用方指定的ID", i+1, id)
+		}
+	}
+}
+
+// unserializableRequest 重写 GetParams 返回无法序列化的参数
+type unserializableRequest struct {
+	CommonJsonRequest
+}
+
+func (r *unserializableRequest) GetParams() map[string]interface{} {
+	return map[string]interface{}{"callback": func() {}}
+}
+
+func (r *unserializableRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	return ex.ErrOrNil()
+}
+
+func TestCheckBaseValidatesEffectiveParams(t *testing.T) {
+	req := &unserializableRequest{CommonJsonRequest: *newTestRequest()}
+	err := req.Check()
+
+	var ruleErr *api.ApiRuleException
+	if !errors.As(err, &ruleErr) {
+		t.Fatalf("Check() error = %v, want ApiRuleException", err)
+	}
+	found := false
+	for _, v := range ruleErr.Violations {
+		if v.Field == "Params" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("应报告 Params 无法序列化，实际 %v", ruleErr.Violations)
+	}
+
+	if err := newTestRequest().Check(); err != nil {
+		t.Errorf("合法请求 Check() error = %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package request
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 编辑终端接口
+	API_EDIT_TERMINAL     = "wsEditTerminal/V1/1Main"
+	API_VER_EDIT_TERMINAL = "V1.1"
+)
+
+// TerminalChangeType 终端变更类型
+type TerminalChangeType string
+
+const (
+	ChangeTypeTerminalId        TerminalChangeType = "1" // 终端ID
+	ChangeTypeSimStatus         TerminalChangeType = "3" // SIM卡状态
+	ChangeTypeRatePlan          TerminalChangeType = "4" // 资费计划
+	ChangeTypeCommunicationPlan TerminalChangeType = "6" // 通信计划
+)
+
+// SIM卡状态
+const (
+	SimStatusActivationReady = "ACTIVATION_READY_NAME" // 可激活
+	SimStatusActivated       = "ACTIVATED_NAME"        // 已激活
+	SimStatusDeactivated     = "DEACTIVATED_NAME"      // 已停用
+	SimStatusRetired         = "RETIRED_NAME"          // 已失效
+	SimStatusTestReady       = "TEST_READY_NAME"       // 可测试
+	SimStatusInventory       = "INVENTORY_NAME"        // 库存
+)
+
+// simStatuses 允许变更到的SIM卡状态
+var simStatuses = map[string]bool{
+	SimStatusActivationReady: true,
+	SimStatusActivated:       true,
+	SimStatusDeactivated:     true,
+	SimStatusRetired:         true,
+	SimStatusTestReady:       true,
+	SimStatusInventory:       true,
+}
+
+// changeTypes 支持的变更类型
+var changeTypes = map[TerminalChangeType]bool{
+	ChangeTypeTerminalId:        true,
+	ChangeTypeSimStatus:         true,
+	ChangeTypeRatePlan:          true,
+	ChangeTypeCommunicationPlan: true,
+}
+
+// IsValidSimStatus 判断是否为允许的SIM卡状态
+func IsValidSimStatus(status string) bool {
+	return simStatuses[status]
+}
+
+// EditTerminalRequest 编辑终端请求（wsEditTerminal），用于变更SIM卡状态、资费计划等属性
+type EditTerminalRequest struct {
+	CommonJsonRequest
+	MessageId   string
+	Version     string
+	Iccid       string
+	ChangeType  TerminalChangeType
+	TargetValue string
+	// EffectiveDate 生效日期，零值表示立即生效，按东八区日期发送
+	EffectiveDate time.Time
+}
+
+// NewEditTerminalRequest 创建一个新的编辑终端请求
+func NewEditTerminalRequest(iccid string, changeType TerminalChangeType, targetValue string) *EditTerminalRequest {
+	r := &EditTerminalRequest{
+		CommonJsonRequest: *NewCommonJsonRequest(),
+		Version:           API_VER_EDIT_TERMINAL,
+		Iccid:             iccid,
+		ChangeType:        changeType,
+		TargetValue:       targetValue,
+	}
+	r.SetApiName(API_EDIT_TERMINAL)
+	r.SetApiVer(API_VER_EDIT_TERMINAL)
+	return r
+}
+
+// NewChangeSimStatusRequest 创建一个变更SIM卡状态的请求
+func NewChangeSimStatusRequest(iccid, status string) *EditTerminalRequest {
+	return NewEditTerminalRequest(iccid, ChangeTypeSimStatus, status)
+}
+
+// GetParams 获取请求参数
+func (r *EditTerminalRequest) GetParams() map[string]interface{} {
+	return r.buildParams(map[string]interface{}{
+		"messageId":     r.MessageId,
+		"version":       r.Version,
+		"iccid":         r.Iccid,
+		"changeType":    string(r.ChangeType),
+		"targetValue":   r.TargetValue,
+		"effectiveDate": formatOptionalDate(r.EffectiveDate),
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *EditTerminalRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.EditTerminalResponse{}
+}
+
+// Check 客户端参数检查，以系统当前时间校验生效日期
+func (r *EditTerminalRequest) Check() error {
+	return r.CheckAt(time.Now())
+}
+
+// CheckAt 以 now 作为当前时间进行客户端参数检查，变更SIM卡状态时目标值必须是允许的状态
+func (r *EditTerminalRequest) CheckAt(now time.Time) error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+
+	if !IsICCID(r.Iccid) {
+		ex.AddViolation("Iccid", "ICCID格式不正确: "+r.Iccid)
+	}
+
+	if !changeTypes[r.ChangeType] {
+		ex.AddViolation("ChangeType", "不支持的变更类型: "+string(r.ChangeType))
+	}
+
+	if r.TargetValue == "" {
+		ex.AddViolation("TargetValue", "不能为空")
+	} else if r.ChangeType == ChangeTypeSimStatus && !IsValidSimStatus(r.TargetValue) {
+		ex.AddViolation("TargetValue", "不支持的SIM卡状态: "+r.TargetValue)
+	}
+
+	// 生效日期不能早于今天（东八区）
+	if !r.EffectiveDate.IsZero() && api.FormatGatewayDate(r.EffectiveDate) < api.FormatGatewayDate(now) {
+		ex.AddViolation("EffectiveDate", "不能早于今天")
+	}
+
+	return ex.ErrOrNil()
+}
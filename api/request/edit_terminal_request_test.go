@@ -0,0 +1,158 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+const testIccid = "89860625680009634556"
+
+// gatewayDate 东八区的指定日期与时刻
+func gatewayDate(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, api.GatewayLocation())
+}
+
+// hasViolation 判断校验错误中是否包含指定字段
+func hasViolation(err error, field string) bool {
+	var ruleErr *api.ApiRuleException
+	if !errors.As(err, &ruleErr) {
+		return false
+	}
+	for _, v := range ruleErr.Violations {
+		if v.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEditTerminalEffectiveDateParam(t *testing.T) {
+	req := NewChangeSimStatusRequest(testIccid, SimStatusActivated)
+	if _, ok := req.GetParams()["effectiveDate"]; ok {
+		t.Error("未设置生效日期时不应发送 effectiveDate")
+	}
+
+	// UTC 16:30 已是东八区次日
+	req.EffectiveDate = time.Date(2024, 1, 1, 16, 30, 0, 0, time.UTC)
+	if got := req.GetParams()["effectiveDate"]; got != "2024-01-02" {
+		t.Errorf("effectiveDate = %v, want 2024-01-02", got)
+	}
+}
+
+func TestEditTerminalCheckAt(t *testing.T) {
+	// 东八区 2024-01-02 00:30，UTC 仍是 1月1日
+	now := gatewayDate(2024, 1, 2, 0, 30)
+
+	tests := []struct {
+		name      string
+		effective time.Time
+		wantErr   bool
+	}{
+		{"立即生效", time.Time{}, false},
+		{"东八区今天", gatewayDate(2024, 1, 2, 0, 0), false},
+		{"明天", gatewayDate(2024, 1, 3, 0, 0), false},
+		{"东八区昨天", gatewayDate(2024, 1, 1, 23, 59), true},
+		{"UTC今天但东八区昨天", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := NewChangeSimStatusRequest(testIccid, SimStatusActivated)
+			req.EffectiveDate = tt.effective
+			err := req.CheckAt(now)
+			if got := hasViolation(err, "EffectiveDate"); got != tt.wantErr {
+				t.Errorf("CheckAt() error = %v, want EffectiveDate 违规 = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEditTerminalCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   *EditTerminalRequest
+		field string
+	}{
+		{"ICCID格式错误", NewChangeSimStatusRequest("123", SimStatusActivated), "Iccid"},
+		{"不支持的SIM卡状态", NewChangeSimStatusRequest(testIccid, "ON"), "TargetValue"},
+		{"目标值为空", NewEditTerminalRequest(testIccid, ChangeTypeRatePlan, ""), "TargetValue"},
+		{"不支持的变更类型", NewEditTerminalRequest(testIccid, "9", "x"), "ChangeType"},
+	}
+
+	for _, tt := range tests {
+		if err := tt.req.Check(); !hasViolation(err, tt.field) {
+			t.Errorf("%s: Check() error = %v, want %s 违规", tt.name, err, tt.field)
+		}
+	}
+	if err := NewEditTerminalRequest(testIccid, ChangeTypeRatePlan, "plan-1").Check(); err != nil {
+		t.Errorf("合法请求 Check() error = %v", err)
+	}
+}
+
+func TestClientChecksDatesWithItsClock(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"0000","message":"成功","data":{}}`))
+	}))
+	defer srv.Close()
+
+	client := api.NewIoTGatewayClient(srv.URL, "app", "secret", "")
+	client.SetClock(api.ClockFunc(func() time.Time {
+		return gatewayDate(2030, 6, 1, 12, 0)
+	}))
+
+	// 系统时间下合法的日期，在客户端时钟下已是过去
+	req := NewChangeSimStatusRequest(testIccid, SimStatusActivated)
+	req.EffectiveDate = gatewayDate(2030, 5, 31, 0, 0)
+	if _, err := client.Execute(req); !hasViolation(err, "EffectiveDate") {
+		t.Errorf("Execute() error = %v, want EffectiveDate 违规", err)
+	}
+
+	usage := NewTerminalUsageRequest(testIccid)
+	usage.CycleStartDate = gatewayDate(2030, 6, 1, 0, 0)
+	if _, err := client.Execute(usage); err != nil {
+		t.Errorf("客户端时钟下的今天应允许，Execute() error = %v", err)
+	}
+}
+
+func TestCycleStartDateCheckAt(t *testing.T) {
+	now := gatewayDate(2024, 3, 15, 8, 0)
+	tests := []struct {
+		name    string
+		date    time.Time
+		wantErr bool
+	}{
+		{"未设置", time.Time{}, false},
+		{"今天", gatewayDate(2024, 3, 15, 23, 0), false},
+		{"过去", gatewayDate(2024, 2, 1, 0, 0), false},
+		{"明天", gatewayDate(2024, 3, 16, 0, 0), true},
+	}
+
+	for _, tt := range tests {
+		usage := NewTerminalUsageRequest(testIccid)
+		usage.CycleStartDate = tt.date
+		byPlan := NewUsageByRatePlanRequest(testIccid)
+		byPlan.CycleStartDate = tt.date
+
+		for _, err := range []error{usage.CheckAt(now), byPlan.CheckAt(now)} {
+			if got := hasViolation(err, "CycleStartDate"); got != tt.wantErr {
+				t.Errorf("%s: CheckAt() error = %v, want CycleStartDate 违规 = %v", tt.name, err, tt.wantErr)
+			}
+		}
+	}
+}
+
+func TestModifiedTerminalsCheckAt(t *testing.T) {
+	now := gatewayDate(2024, 3, 15, 8, 0)
+
+	if err := NewModifiedTerminalsRequest(now.Add(-time.Hour)).CheckAt(now); err != nil {
+		t.Errorf("过去的起始时间 CheckAt() error = %v", err)
+	}
+	if err := NewModifiedTerminalsRequest(now.Add(time.Hour)).CheckAt(now); !hasViolation(err, "Since") {
+		t.Errorf("未来的起始时间 CheckAt() error = %v, want Since 违规", err)
+	}
+}
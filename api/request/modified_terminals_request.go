@@ -0,0 +1,178 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 变更终端查询接口
+	API_MODIFIED_TERMINALS     = "wsGetModifiedTerminals/V1/1Main"
+	API_VER_MODIFIED_TERMINALS = "V1.1"
+
+	// MaxModifiedTerminalsPageSize 变更终端查询接口单页的最大条数
+	MaxModifiedTerminalsPageSize = 50
+)
+
+// ModifiedTerminalsRequest 查询指定时间之后有变更的终端请求（wsGetModifiedTerminals）
+type ModifiedTerminalsRequest struct {
+	CommonJsonRequest
+	pagination
+	MessageId string
+	Version   string
+	// Since 变更起始时间（含），按东八区 yyyy-MM-dd HH:mm:ss 发送
+	Since time.Time
+
+	// fromCursor 起始时间来自同步游标，即网关时钟下的变更时间
+	fromCursor bool
+}
+
+// NewModifiedTerminalsRequest 创建一个查询 since 之后有变更的终端的请求
+func NewModifiedTerminalsRequest(since time.Time) *ModifiedTerminalsRequest {
+	r := &ModifiedTerminalsRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_MODIFIED_TERMINALS,
+		Since:             since,
+	}
+	r.SetApiName(API_MODIFIED_TERMINALS)
+	r.SetApiVer(API_VER_MODIFIED_TERMINALS)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *ModifiedTerminalsRequest) GetParams() map[string]interface{} {
+	var since string
+	if !r.Since.IsZero() {
+		since = api.FormatGatewayTime(r.Since)
+	}
+
+	pageSize, pageNumber := r.pageParams()
+	return r.buildParams(map[string]interface{}{
+		"messageId":  r.MessageId,
+		"version":    r.Version,
+		"since":      since,
+		"pageSize":   pageSize,
+		"pageNumber": pageNumber,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *ModifiedTerminalsRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.ModifiedTerminalsResponse{}
+}
+
+// Check 客户端参数检查，以系统当前时间校验起始时间
+func (r *ModifiedTerminalsRequest) Check() error {
+	return r.CheckAt(time.Now())
+}
+
+// CheckAt 以 now 作为当前时间进行客户端参数检查，起始时间不能为空且不能晚于当前时间
+//
+// 由同步游标构建的请求，起始时间取自网关返回的变更时间，网关时钟快于本地时可能晚于本地当前时间，不做该检查。
+func (r *ModifiedTerminalsRequest) CheckAt(now time.Time) error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	r.checkPage(ex, MaxModifiedTerminalsPageSize)
+
+	if r.Since.IsZero() {
+		ex.AddViolation("Since", "不能为空")
+	} else if !r.fromCursor && r.Since.After(now) {
+		ex.AddViolation("Since", "不能晚于当前时间")
+	}
+
+	return ex.ErrOrNil()
+}
+
+// ModifiedTerminalsCursor 增量同步游标，记录已同步到的最后变更时间
+//
+// 游标可通过 MarshalText/UnmarshalText（或 String/ParseModifiedTerminalsCursor）持久化，
+// 下次同步从该时间继续。
+type ModifiedTerminalsCursor struct {
+	Since time.Time
+}
+
+// String 将游标格式化为 RFC3339 字符串，零值游标返回空串
+func (c ModifiedTerminalsCursor) String() string {
+	if c.Since.IsZero() {
+		return ""
+	}
+	return c.Since.Format(time.RFC3339Nano)
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (c ModifiedTerminalsCursor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (c *ModifiedTerminalsCursor) UnmarshalText(text []byte) error {
+	cursor, err := ParseModifiedTerminalsCursor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = cursor
+	return nil
+}
+
+// ParseModifiedTerminalsCursor 解析 String 输出的游标，空串返回零值游标
+func ParseModifiedTerminalsCursor(s string) (ModifiedTerminalsCursor, error) {
+	if s == "" {
+		return ModifiedTerminalsCursor{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return ModifiedTerminalsCursor{}, fmt.Errorf("无法解析同步游标: %s", s)
+	}
+	return ModifiedTerminalsCursor{Since: t}, nil
+}
+
+// SyncModifiedTerminals 自动翻页查询游标之后有变更的全部终端，并返回推进后的游标
+//
+// 起始时间包含在查询范围内，恰好位于游标时间的变更可能在下一次同步中再次返回，调用方应按ICCID幂等处理。
+// 本次没有新的变更时返回原游标。翻页达到上限仍未取完时返回已获取的终端、未推进的原游标与 ErrPageLimitExceeded。
+// 游标不能为零值：首次同步时以 ModifiedTerminalsCursor{Since: 起始时间} 指定从何时开始，
+// 零值游标不访问网关，直接返回 Since 不能为空的 ApiRuleException。
+func SyncModifiedTerminals(ctx context.Context, client api.IoTGatewayClient, cursor ModifiedTerminalsCursor) ([]response.ModifiedTerminal, ModifiedTerminalsCursor, error) {
+	var terminals []response.ModifiedTerminal
+	next := cursor
+
+	for page := 1; ; page++ {
+		if page > maxListPages {
+			return terminals, cursor, ErrPageLimitExceeded
+		}
+
+		req := NewModifiedTerminalsRequest(cursor.Since)
+		req.fromCursor = true
+		req.PageSize = MaxModifiedTerminalsPageSize
+		req.PageNumber = page
+
+		resp, err := executeSuccessful(ctx, client, req)
+		if err != nil {
+			return nil, cursor, err
+		}
+		modifiedResp, ok := resp.(*response.ModifiedTerminalsResponse)
+		if !ok {
+			return nil, cursor, fmt.Errorf("变更终端响应类型不正确: %T", resp)
+		}
+
+		for _, t := range modifiedResp.GetTerminals() {
+			modified, err := t.GetDateModified()
+			if err != nil {
+				return nil, cursor, err
+			}
+			if modified.After(next.Since) {
+				next.Since = modified
+			}
+		}
+		terminals = append(terminals, modifiedResp.GetTerminals()...)
+
+		if modifiedResp.IsLastPage() || len(modifiedResp.GetTerminals()) < MaxModifiedTerminalsPageSize {
+			break
+		}
+	}
+	return terminals, next, nil
+}
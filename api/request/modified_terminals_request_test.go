@@ -0,0 +1,128 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// modifiedTerminalsPage 构造一页变更终端数据，第 i 个终端的变更时间为 start 之后 i 分钟
+func modifiedTerminalsPage(start time.Time, count int, lastPage bool) string {
+	terminals := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		terminals = append(terminals, fmt.Sprintf(`{"iccid":"898606256800096%05d","dateModified":"%s"}`,
+			i, api.FormatGatewayTime(start.Add(time.Duration(i)*time.Minute))))
+	}
+	return fmt.Sprintf(`{"terminals":[%s],"lastPage":%t}`, strings.Join(terminals, ","), lastPage)
+}
+
+func TestSyncModifiedTerminalsAdvancesCursorAcrossPages(t *testing.T) {
+	since := gatewayDate(2024, 3, 1, 0, 0)
+	firstPage := gatewayDate(2024, 3, 1, 8, 0)
+	secondPage := gatewayDate(2024, 3, 2, 8, 0)
+
+	srv := newScriptedServer(t, func(call gatewayCall) string {
+		if call.Data["pageNumber"] == "1" {
+			return modifiedTerminalsPage(firstPage, MaxModifiedTerminalsPageSize, false)
+		}
+		return modifiedTerminalsPage(secondPage, 3, true)
+	})
+
+	terminals, next, err := SyncModifiedTerminals(context.Background(), srv.client(), ModifiedTerminalsCursor{Since: since})
+	if err != nil {
+		t.Fatalf("SyncModifiedTerminals() error = %v", err)
+	}
+	if len(terminals) != MaxModifiedTerminalsPageSize+3 {
+		t.Errorf("获取到 %d 个终端, want %d", len(terminals), MaxModifiedTerminalsPageSize+3)
+	}
+	if want := secondPage.Add(2 * time.Minute); !next.Since.Equal(want) {
+		t.Errorf("推进后的游标 = %v, want %v", next.Since, want)
+	}
+
+	calls := srv.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("网关收到 %d 次请求, want 2", len(calls))
+	}
+	for i, call := range calls {
+		if got, want := call.Data["since"], api.FormatGatewayTime(since); got != want {
+			t.Errorf("第%d页 since = %v, want %v", i+1, got, want)
+		}
+		if got, want := call.Data["pageNumber"], fmt.Sprint(i+1); got != want {
+			t.Errorf("第%d页 pageNumber = %v, want %v", i+1, got, want)
+		}
+		if got, want := call.Data["pageSize"], fmt.Sprint(MaxModifiedTerminalsPageSize); got != want {
+			t.Errorf("第%d页 pageSize = %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestSyncModifiedTerminalsStopsOnShortPage(t *testing.T) {
+	srv := newScriptedServer(t, func(call gatewayCall) string {
+		// 网关未标记最后一页，但本页不足一页
+		return modifiedTerminalsPage(gatewayDate(2024, 3, 1, 8, 0), 2, false)
+	})
+
+	terminals, _, err := SyncModifiedTerminals(context.Background(), srv.client(),
+		ModifiedTerminalsCursor{Since: gatewayDate(2024, 3, 1, 0, 0)})
+	if err != nil {
+		t.Fatalf("SyncModifiedTerminals() error = %v", err)
+	}
+	if len(terminals) != 2 || len(srv.Calls()) != 1 {
+		t.Errorf("获取到 %d 个终端、%d 次请求, want 2 个终端、1 次请求", len(terminals), len(srv.Calls()))
+	}
+}
+
+func TestSyncModifiedTerminalsKeepsCursorWithoutChanges(t *testing.T) {
+	srv := newScriptedServer(t, func(call gatewayCall) string {
+		return `{"terminals":[],"lastPage":true}`
+	})
+	cursor := ModifiedTerminalsCursor{Since: gatewayDate(2024, 3, 1, 0, 0)}
+
+	terminals, next, err := SyncModifiedTerminals(context.Background(), srv.client(), cursor)
+	if err != nil {
+		t.Fatalf("SyncModifiedTerminals() error = %v", err)
+	}
+	if len(terminals) != 0 || next != cursor {
+		t.Errorf("没有变更时返回 %d 个终端、游标 %v, want 0 个终端、原游标", len(terminals), next)
+	}
+}
+
+func TestSyncModifiedTerminalsPageLimit(t *testing.T) {
+	srv := newScriptedServer(t, func(call gatewayCall) string {
+		return modifiedTerminalsPage(gatewayDate(2024, 3, 1, 8, 0), MaxModifiedTerminalsPageSize, false)
+	})
+	cursor := ModifiedTerminalsCursor{Since: gatewayDate(2024, 3, 1, 0, 0)}
+
+	terminals, next, err := SyncModifiedTerminals(context.Background(), srv.client(), cursor)
+	if !errors.Is(err, ErrPageLimitExceeded) {
+		t.Fatalf("SyncModifiedTerminals() error = %v, want ErrPageLimitExceeded", err)
+	}
+	if next != cursor {
+		t.Errorf("结果不完整时游标 = %v, want 原游标 %v", next, cursor)
+	}
+	if len(terminals) != maxListPages*MaxModifiedTerminalsPageSize {
+		t.Errorf("获取到 %d 个终端, want %d", len(terminals), maxListPages*MaxModifiedTerminalsPageSize)
+	}
+	if n := len(srv.Calls()); n != maxListPages {
+		t.Errorf("网关收到 %d 次请求, want %d", n, maxListPages)
+	}
+}
+
+func TestSyncModifiedTerminalsRejectsZeroCursor(t *testing.T) {
+	srv := newScriptedServer(t, func(call gatewayCall) string {
+		return `{"terminals":[],"lastPage":true}`
+	})
+
+	_, _, err := SyncModifiedTerminals(context.Background(), srv.client(), ModifiedTerminalsCursor{})
+	if !hasViolation(err, "Since") {
+		t.Errorf("零值游标 error = %v, want Since 违规", err)
+	}
+	if n := len(srv.Calls()); n != 0 {
+		t.Errorf("网关收到 %d 次请求, want 0", n)
+	}
+}
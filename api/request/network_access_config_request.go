@@ -0,0 +1,274 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 查询网络接入配置接口
+	API_NETWORK_ACCESS_CONFIG     = "wsGetNetworkAccessConfig/V1/1Main"
+	API_VER_NETWORK_ACCESS_CONFIG = "V1.1"
+
+	// 编辑网络接入配置接口
+	API_EDIT_NETWORK_ACCESS_CONFIG     = "wsEditNetworkAccessConfig/V1/1Main"
+	API_VER_EDIT_NETWORK_ACCESS_CONFIG = "V1.1"
+
+	// MaxNetworkAccessApns 单个网络接入配置的最大APN数量
+	MaxNetworkAccessApns = 10
+)
+
+// IpAllocation IP地址分配方式
+type IpAllocation string
+
+const (
+	IpAllocationDynamic IpAllocation = "DYNAMIC" // 动态分配
+	IpAllocationStatic  IpAllocation = "STATIC"  // 从静态地址池分配
+)
+
+// RoamingRestriction 漫游限制
+type RoamingRestriction string
+
+const (
+	RoamingUnrestricted RoamingRestriction = "NONE"       // 不限制
+	RoamingHomeOnly     RoamingRestriction = "HOME_ONLY"  // 禁止漫游
+	RoamingAllowList    RoamingRestriction = "ALLOW_LIST" // 只允许漫游到指定运营商
+	RoamingDenyList     RoamingRestriction = "DENY_LIST"  // 禁止漫游到指定运营商
+)
+
+var (
+	// apnPattern APN名称格式：字母、数字、点和连字符，最长63个字符
+	apnPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.\-]{0,62}$`)
+
+	// plmnPattern 运营商PLMN格式：MCC+MNC，5~6位数字
+	plmnPattern = regexp.MustCompile(`^[0-9]{5,6}$`)
+)
+
+// ApnSetting APN设置
+type ApnSetting struct {
+	Name         string
+	IpAllocation IpAllocation
+	// IpPool 静态地址池名称，仅 IpAllocationStatic 时填写
+	IpPool string
+	// Default 是否为默认APN，配置中必须有且只有一个默认APN
+	Default bool
+}
+
+// params 转换为请求参数
+func (a ApnSetting) params() map[string]interface{} {
+	p := map[string]interface{}{
+		"apnName":      a.Name,
+		"ipAllocation": string(a.IpAllocation),
+		"isDefault":    a.Default,
+	}
+	if a.IpPool != "" {
+		p["ipPool"] = a.IpPool
+	}
+	return p
+}
+
+// NetworkAccessConfigRequest 查询网络接入配置请求（wsGetNetworkAccessConfig），按配置ID或ICCID二选一查询
+type NetworkAccessConfigRequest struct {
+	CommonJsonRequest
+	MessageId string
+	Version   string
+	NacId     string
+	Iccid     string
+}
+
+// NewNetworkAccessConfigRequest 创建一个按配置ID查询网络接入配置的请求
+func NewNetworkAccessConfigRequest(nacId string) *NetworkAccessConfigRequest {
+	r := newNetworkAccessConfigRequest()
+	r.NacId = nacId
+	return r
+}
+
+// NewTerminalNetworkAccessConfigRequest 创建一个查询终端所用网络接入配置的请求
+func NewTerminalNetworkAccessConfigRequest(iccid string) *NetworkAccessConfigRequest {
+	r := newNetworkAccessConfigRequest()
+	r.Iccid = iccid
+	return r
+}
+
+// newNetworkAccessConfigRequest 创建未指定查询条件的网络接入配置请求
+func newNetworkAccessConfigRequest() *NetworkAccessConfigRequest {
+	r := &NetworkAccessConfigRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_NETWORK_ACCESS_CONFIG,
+	}
+	r.SetApiName(API_NETWORK_ACCESS_CONFIG)
+	r.SetApiVer(API_VER_NETWORK_ACCESS_CONFIG)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *NetworkAccessConfigRequest) GetParams() map[string]interface{} {
+	return r.buildParams(map[string]interface{}{
+		"messageId": r.MessageId,
+		"version":   r.Version,
+		"nacId":     r.NacId,
+		"iccid":     r.Iccid,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *NetworkAccessConfigRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.NetworkAccessConfigResponse{}
+}
+
+// Check 客户端参数检查，配置ID与ICCID必须且只能指定一个
+func (r *NetworkAccessConfigRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+
+	switch {
+	case r.NacId == "" && r.Iccid == "":
+		ex.AddViolation("NacId", "配置ID与ICCID必须指定一个")
+	case r.NacId != "" && r.Iccid != "":
+		ex.AddViolation("NacId", "配置ID与ICCID只能指定一个")
+	case r.Iccid != "" && !IsICCID(r.Iccid):
+		ex.AddViolation("Iccid", "ICCID格式不正确: "+r.Iccid)
+	}
+
+	return ex.ErrOrNil()
+}
+
+// EditNetworkAccessConfigRequest 编辑网络接入配置请求（wsEditNetworkAccessConfig）
+//
+// 未设置的字段不发送，保持网关中的原值；Apns 非空时整体替换原有APN列表。
+type EditNetworkAccessConfigRequest struct {
+	CommonJsonRequest
+	MessageId string
+	Version   string
+	NacId     string
+	Apns      []ApnSetting
+	// RoamingRestriction 漫游限制，为空时不修改
+	RoamingRestriction RoamingRestriction
+	// RoamingOperators 运营商PLMN列表，仅 RoamingAllowList、RoamingDenyList 时填写
+	RoamingOperators []string
+}
+
+// NewEditNetworkAccessConfigRequest 创建一个新的编辑网络接入配置请求
+func NewEditNetworkAccessConfigRequest(nacId string) *EditNetworkAccessConfigRequest {
+	r := &EditNetworkAccessConfigRequest{
+		CommonJsonRequest: *NewCommonJsonRequest(),
+		Version:           API_VER_EDIT_NETWORK_ACCESS_CONFIG,
+		NacId:             nacId,
+	}
+	r.SetApiName(API_EDIT_NETWORK_ACCESS_CONFIG)
+	r.SetApiVer(API_VER_EDIT_NETWORK_ACCESS_CONFIG)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *EditNetworkAccessConfigRequest) GetParams() map[string]interface{} {
+	apns := make([]interface{}, 0, len(r.Apns))
+	for _, a := range r.Apns {
+		apns = append(apns, a.params())
+	}
+
+	return r.buildParams(map[string]interface{}{
+		"messageId":          r.MessageId,
+		"version":            r.Version,
+		"nacId":              r.NacId,
+		"apns":               apns,
+		"roamingRestriction": string(r.RoamingRestriction),
+		"roamingOperators":   r.RoamingOperators,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *EditNetworkAccessConfigRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.EditNetworkAccessConfigResponse{}
+}
+
+// Check 客户端参数检查，拒绝APN与漫游设置中互相矛盾的组合
+func (r *EditNetworkAccessConfigRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+
+	if r.NacId == "" {
+		ex.AddViolation("NacId", "不能为空")
+	}
+	if len(r.Apns) == 0 && r.RoamingRestriction == "" && len(r.RoamingOperators) == 0 {
+		ex.AddViolation("Apns", "APN与漫游设置至少需要修改一项")
+	}
+
+	r.checkApns(ex)
+	r.checkRoaming(ex)
+
+	return ex.ErrOrNil()
+}
+
+// checkApns 检查APN列表：名称合法且不重复、地址分配方式与地址池匹配、有且只有一个默认APN
+func (r *EditNetworkAccessConfigRequest) checkApns(ex *api.ApiRuleException) {
+	if len(r.Apns) == 0 {
+		return
+	}
+	if len(r.Apns) > MaxNetworkAccessApns {
+		ex.AddViolation("Apns", fmt.Sprintf("最多%d个，实际%d个", MaxNetworkAccessApns, len(r.Apns)))
+	}
+
+	names := make(map[string]bool, len(r.Apns))
+	defaults := 0
+	for i, a := range r.Apns {
+		field := fmt.Sprintf("Apns[%d]", i)
+
+		if !apnPattern.MatchString(a.Name) {
+			ex.AddViolation(field+".Name", "APN名称格式不正确: "+a.Name)
+		} else if names[a.Name] {
+			ex.AddViolation(field+".Name", "APN名称重复: "+a.Name)
+		}
+		names[a.Name] = true
+
+		switch a.IpAllocation {
+		case IpAllocationDynamic:
+			if a.IpPool != "" {
+				ex.AddViolation(field+".IpPool", "动态分配时不能指定地址池")
+			}
+		case IpAllocationStatic:
+			if a.IpPool == "" {
+				ex.AddViolation(field+".IpPool", "静态分配时必须指定地址池")
+			}
+		default:
+			ex.AddViolation(field+".IpAllocation", "不支持的地址分配方式: "+string(a.IpAllocation))
+		}
+
+		if a.Default {
+			defaults++
+		}
+	}
+
+	if defaults != 1 {
+		ex.AddViolation("Apns", fmt.Sprintf("必须有且只有一个默认APN，实际%d个", defaults))
+	}
+}
+
+// checkRoaming 检查漫游限制与运营商列表是否匹配
+func (r *EditNetworkAccessConfigRequest) checkRoaming(ex *api.ApiRuleException) {
+	switch r.RoamingRestriction {
+	case "":
+		if len(r.RoamingOperators) > 0 {
+			ex.AddViolation("RoamingOperators", "指定运营商列表时必须设置漫游限制")
+		}
+	case RoamingUnrestricted, RoamingHomeOnly:
+		if len(r.RoamingOperators) > 0 {
+			ex.AddViolation("RoamingOperators", string(r.RoamingRestriction)+"不能指定运营商列表")
+		}
+	case RoamingAllowList, RoamingDenyList:
+		if len(r.RoamingOperators) == 0 {
+			ex.AddViolation("RoamingOperators", string(r.RoamingRestriction)+"必须指定运营商列表")
+		}
+	default:
+		ex.AddViolation("RoamingRestriction", "不支持的漫游限制: "+string(r.RoamingRestriction))
+	}
+
+	for i, plmn := range r.RoamingOperators {
+		if !plmnPattern.MatchString(plmn) {
+			ex.AddViolation(fmt.Sprintf("RoamingOperators[%d]", i), "PLMN格式不正确: "+plmn)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// apn 构造APN设置
+func apn(name string, allocation IpAllocation, pool string, isDefault bool) ApnSetting {
+	return ApnSetting{Name: name, IpAllocation: allocation, IpPool: pool, Default: isDefault}
+}
+
+func TestNetworkAccessConfigCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		nacId string
+		iccid string
+		field string // 期望的违规字段，空表示合法
+	}{
+		{"按配置ID查询", "1001", "", ""},
+		{"按ICCID查询", "", testIccid, ""},
+		{"均未指定", "", "", "NacId"},
+		{"同时指定", "1001", testIccid, "NacId"},
+		{"ICCID格式错误", "", "123", "Iccid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newNetworkAccessConfigRequest()
+			req.NacId, req.Iccid = tt.nacId, tt.iccid
+			err := req.Check()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("Check() error = %v, want nil", err)
+				}
+				return
+			}
+			if !hasViolation(err, tt.field) {
+				t.Errorf("Check() error = %v, want %s 违规", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestEditNetworkAccessConfigCheck(t *testing.T) {
+	tooMany := make([]ApnSetting, MaxNetworkAccessApns+1)
+	for i := range tooMany {
+		tooMany[i] = apn(fmt.Sprintf("apn%d", i), IpAllocationDynamic, "", i == 0)
+	}
+
+	tests := []struct {
+		name        string
+		nacId       string
+		apns        []ApnSetting
+		restriction RoamingRestriction
+		operators   []string
+		field       string // 期望的违规字段，空表示合法
+	}{
+		// 合法组合
+		{name: "仅动态APN", nacId: "1001", apns: []ApnSetting{apn("cmiot", IpAllocationDynamic, "", true)}},
+		{name: "动态与静态APN", nacId: "1001", apns: []ApnSetting{
+			apn("cmiot", IpAllocationDynamic, "", true),
+			apn("private.apn", IpAllocationStatic, "pool-1", false),
+		}},
+		{name: "不限制漫游", nacId: "1001", restriction: RoamingUnrestricted},
+		{name: "禁止漫游", nacId: "1001", restriction: RoamingHomeOnly},
+		{name: "允许列表", nacId: "1001", restriction: RoamingAllowList, operators: []string{"46001", "460011"}},
+		{name: "禁止列表", nacId: "1001", restriction: RoamingDenyList, operators: []string{"46000"}},
+		{name: "APN与漫游同时修改", nacId: "1001", apns: []ApnSetting{apn("cmiot", IpAllocationDynamic, "", true)}, restriction: RoamingHomeOnly},
+
+		// 非法组合
+		{name: "配置ID为空", restriction: RoamingHomeOnly, field: "NacId"},
+		{name: "没有修改项", nacId: "1001", field: "Apns"},
+		{name: "APN过多", nacId: "1001", apns: tooMany, field: "Apns"},
+		{name: "APN名称为空", nacId: "1001", apns: []ApnSetting{apn("", IpAllocationDynamic, "", true)}, field: "Apns[0].Name"},
+		{name: "APN名称非法字符", nacId: "1001", apns: []ApnSetting{apn("cm_iot", IpAllocationDynamic, "", true)}, field: "Apns[0].Name"},
+		{name: "APN名称过长", nacId: "1001", apns: []ApnSetting{apn(strings.Repeat("a", 64), IpAllocationDynamic, "", true)}, field: "Apns[0].Name"},
+		{name: "APN名称重复", nacId: "1001", apns: []ApnSetting{
+			apn("cmiot", IpAllocationDynamic, "", true),
+			apn("cmiot", IpAllocationDynamic, "", false),
+		}, field: "Apns[1].Name"},
+		{name: "动态分配指定地址池", nacId: "1001", apns: []ApnSetting{apn("cmiot", IpAllocationDynamic, "pool-1", true)}, field: "Apns[0].IpPool"},
+		{name: "静态分配未指定地址池", nacId: "1001", apns: []ApnSetting{apn("cmiot", IpAllocationStatic, "", true)}, field: "Apns[0].IpPool"},
+		{name: "未知地址分配方式", nacId: "1001", apns: []ApnSetting{apn("cmiot", "DHCP", "", true)}, field: "Apns[0].IpAllocation"},
+		{name: "没有默认APN", nacId: "1001", apns: []ApnSetting{apn("cmiot", IpAllocationDynamic, "", false)}, field: "Apns"},
+		{name: "多个默认APN", nacId: "1001", apns: []ApnSetting{
+			apn("cmiot", IpAllocationDynamic, "", true),
+			apn("backup", IpAllocationDynamic, "", true),
+		}, field: "Apns"},
+		{name: "运营商列表缺少漫游限制", nacId: "1001", operators: []string{"46001"}, field: "RoamingOperators"},
+		{name: "不限制漫游指定运营商", nacId: "1001", restriction: RoamingUnrestricted, operators: []string{"46001"}, field: "RoamingOperators"},
+		{name: "禁止漫游指定运营商", nacId: "1001", restriction: RoamingHomeOnly, operators: []string{"46001"}, field: "RoamingOperators"},
+		{name: "允许列表为空", nacId: "1001", restriction: RoamingAllowList, field: "RoamingOperators"},
+		{name: "禁止列表为空", nacId: "1001", restriction: RoamingDenyList, field: "RoamingOperators"},
+		{name: "未知漫游限制", nacId: "1001", restriction: "PARTIAL", field: "RoamingRestriction"},
+		{name: "PLMN格式错误", nacId: "1001", restriction: RoamingAllowList, operators: []string{"46001", "4600"}, field: "RoamingOperators[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := NewEditNetworkAccessConfigRequest(tt.nacId)
+			req.Apns = tt.apns
+			req.RoamingRestriction = tt.restriction
+			req.RoamingOperators = tt.operators
+
+			err := req.Check()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("Check() error = %v, want nil", err)
+				}
+				return
+			}
+			if !hasViolation(err, tt.field) {
+				t.Errorf("Check() error = %v, want %s 违规", err, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// maxListPages 自动翻页时的最大页数，防止网关始终不返回最后一页时无限翻页
+const maxListPages = 1000
+
+// ErrPageLimitExceeded 自动翻页达到最大页数仍未取完，结果不完整
+var ErrPageLimitExceeded = fmt.Errorf("自动翻页超过%d页仍未取完", maxListPages)
+
+// iccidPattern ICCID格式：以89开头的19~20位数字或字母
+var iccidPattern = regexp.MustCompile(`^89[0-9A-Za-z]{17,18}$`)
+
+// IsICCID 判断是否为ICCID格式
+func IsICCID(s string) bool {
+	return iccidPattern.MatchString(s)
+}
+
+// buildParams 以 Params 中的自定义参数为基础叠加类型化字段，空字符串、nil及空切片不发送
+func (r *CommonJsonRequest) buildParams(fields map[string]interface{}) map[string]interface{} {
+	params := make(map[string]interface{}, len(r.Params)+len(fields))
+	for k, v := range r.Params {
+		params[k] = v
+	}
+	for k, v := range fields {
+		if isEmptyParam(v) {
+			continue
+		}
+		params[k] = v
+	}
+	return params
+}
+
+// isEmptyParam 判断参数是否为空值
+func isEmptyParam(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case []string:
+		return len(val) == 0
+	case []interface{}:
+		return len(val) == 0
+	case map[string]interface{}:
+		return len(val) == 0
+	}
+	return false
+}
+
+// checkIccids 检查ICCID列表不为空、数量不超过 max 且格式正确
+func checkIccids(ex *api.ApiRuleException, field string, iccids []string, max int) {
+	if len(iccids) == 0 {
+		ex.AddViolation(field, "不能为空")
+		return
+	}
+	if max > 0 && len(iccids) > max {
+		ex.AddViolation(field, fmt.Sprintf("单次最多%d个，实际%d个", max, len(iccids)))
+	}
+	for i, iccid := range iccids {
+		if !IsICCID(iccid) {
+			ex.AddViolation(fmt.Sprintf("%s[%d]", field, i), "ICCID格式不正确: "+iccid)
+		}
+	}
+}
+
+// formatOptionalDate 按东八区日期格式化，零值返回空串（不发送）
+func formatOptionalDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return api.FormatGatewayDate(t)
+}
+
+// checkCycleStartDate 检查计费周期开始日期不晚于 now 所在的当天（东八区）
+func checkCycleStartDate(ex *api.ApiRuleException, cycleStartDate, now time.Time) {
+	if cycleStartDate.IsZero() {
+		return
+	}
+	if api.FormatGatewayDate(cycleStartDate) > api.FormatGatewayDate(now) {
+		ex.AddViolation("CycleStartDate", "不能晚于今天")
+	}
+}
+
+// pagination 列表接口的分页参数
+type pagination struct {
+	// PageSize 每页条数，0表示使用网关默认值
+	PageSize int
+	// PageNumber 页码，从1开始，0表示第一页
+	PageNumber int
+}
+
+// pageParams 分页参数，零值不发送
+func (p pagination) pageParams() (pageSize, pageNumber string) {
+	if p.PageSize > 0 {
+		pageSize = strconv.Itoa(p.PageSize)
+	}
+	if p.PageNumber > 0 {
+		pageNumber = strconv.Itoa(p.PageNumber)
+	}
+	return pageSize, pageNumber
+}
+
+// checkPage 检查分页参数，每页条数不能超过 maxPageSize
+func (p pagination) checkPage(ex *api.ApiRuleException, maxPageSize int) {
+	if p.PageSize < 0 || p.PageSize > maxPageSize {
+		ex.AddViolation("PageSize", fmt.Sprintf("取值范围为0~%d，实际%d", maxPageSize, p.PageSize))
+	}
+	if p.PageNumber < 0 {
+		ex.AddViolation("PageNumber", fmt.Sprintf("不能小于0，实际%d", p.PageNumber))
+	}
+}
+
+// executeSuccessful 执行请求，网关返回失败状态时转换为 ApiException
+func executeSuccessful(ctx context.Context, client api.IoTGatewayClient, req api.IoTGatewayRequest) (api.IoTGatewayResponse, error) {
+	resp, err := client.ExecuteContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, api.NewApiException("网关未返回响应: "+req.GetApiName(), "", nil)
+	}
+	if !resp.IsSuccess() {
+		return nil, &api.ApiException{
+			ErrMsg:  resp.GetMessage(),
+			ErrCode: resp.GetStatus(),
+			Body:    resp.GetBody(),
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,215 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+// PlanCatalog 资费计划与通信计划目录的内存缓存，可按名称或ID查找
+//
+// 目录在调用 Refresh 后才有数据，刷新失败时保留上一次加载的内容。可并发使用。
+type PlanCatalog struct {
+	client api.IoTGatewayClient
+
+	mu                       sync.RWMutex
+	ratePlansByName          map[string]response.RatePlan
+	ratePlansById            map[string]response.RatePlan
+	communicationPlansByName map[string]response.CommunicationPlan
+	communicationPlansById   map[string]response.CommunicationPlan
+	refreshedAt              time.Time
+}
+
+// NewPlanCatalog 创建一个通过 client 加载的计划目录
+func NewPlanCatalog(client api.IoTGatewayClient) *PlanCatalog {
+	return &PlanCatalog{client: client}
+}
+
+// Refresh 分页加载全部资费计划与通信计划，成功后整体替换目录；翻页达到上限仍未取完时返回 ErrPageLimitExceeded 并保留原目录
+func (c *PlanCatalog) Refresh(ctx context.Context) error {
+	ratePlans, err := c.loadRatePlans(ctx)
+	if err != nil {
+		return err
+	}
+	communicationPlans, err := c.loadCommunicationPlans(ctx)
+	if err != nil {
+		return err
+	}
+
+	ratePlansByName := make(map[string]response.RatePlan, len(ratePlans))
+	ratePlansById := make(map[string]response.RatePlan, len(ratePlans))
+	for _, p := range ratePlans {
+		ratePlansByName[p.RatePlanName] = p
+		ratePlansById[p.RatePlanId.String()] = p
+	}
+	communicationPlansByName := make(map[string]response.CommunicationPlan, len(communicationPlans))
+	communicationPlansById := make(map[string]response.CommunicationPlan, len(communicationPlans))
+	for _, p := range communicationPlans {
+		communicationPlansByName[p.CommunicationPlanName] = p
+		communicationPlansById[p.CommunicationPlanId.String()] = p
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ratePlansByName = ratePlansByName
+	c.ratePlansById = ratePlansById
+	c.communicationPlansByName = communicationPlansByName
+	c.communicationPlansById = communicationPlansById
+	c.refreshedAt = time.Now()
+	return nil
+}
+
+// loadRatePlans 逐页加载资费计划
+func (c *PlanCatalog) loadRatePlans(ctx context.Context) ([]response.RatePlan, error) {
+	var plans []response.RatePlan
+	for page := 1; ; page++ {
+		if page > maxListPages {
+			return nil, ErrPageLimitExceeded
+		}
+
+		req := NewRatePlansRequest()
+		req.PageSize = MaxPlanPageSize
+		req.PageNumber = page
+
+		resp, err := executeSuccessful(ctx, c.client, req)
+		if err != nil {
+			return nil, err
+		}
+		ratePlansResp, ok := resp.(*response.RatePlansResponse)
+		if !ok {
+			return nil, fmt.Errorf("资费计划列表响应类型不正确: %T", resp)
+		}
+
+		plans = append(plans, ratePlansResp.GetRatePlans()...)
+		if ratePlansResp.IsLastPage() || len(ratePlansResp.GetRatePlans()) < MaxPlanPageSize {
+			break
+		}
+	}
+	return plans, nil
+}
+
+// loadCommunicationPlans 逐页加载通信计划
+func (c *PlanCatalog) loadCommunicationPlans(ctx context.Context) ([]response.CommunicationPlan, error) {
+	var plans []response.CommunicationPlan
+	for page := 1; ; page++ {
+		if page > maxListPages {
+			return nil, ErrPageLimitExceeded
+		}
+
+		req := NewCommunicationPlansRequest()
+		req.PageSize = MaxPlanPageSize
+		req.PageNumber = page
+
+		resp, err := executeSuccessful(ctx, c.client, req)
+		if err != nil {
+			return nil, err
+		}
+		communicationPlansResp, ok := resp.(*response.CommunicationPlansResponse)
+		if !ok {
+			return nil, fmt.Errorf("通信计划列表响应类型不正确: %T", resp)
+		}
+
+		plans = append(plans, communicationPlansResp.GetCommunicationPlans()...)
+		if communicationPlansResp.IsLastPage() || len(communicationPlansResp.GetCommunicationPlans()) < MaxPlanPageSize {
+			break
+		}
+	}
+	return plans, nil
+}
+
+// RatePlanByName 按名称查找资费计划
+func (c *PlanCatalog) RatePlanByName(name string) (response.RatePlan, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	p, ok := c.ratePlansByName[name]
+	return p, ok
+}
+
+// RatePlanById 按ID查找资费计划
+func (c *PlanCatalog) RatePlanById(id string) (response.RatePlan, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	p, ok := c.ratePlansById[id]
+	return p, ok
+}
+
+// CommunicationPlanByName 按名称查找通信计划
+func (c *PlanCatalog) CommunicationPlanByName(name string) (response.CommunicationPlan, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	p, ok := c.communicationPlansByName[name]
+	return p, ok
+}
+
+// CommunicationPlanById 按ID查找通信计划
+func (c *PlanCatalog) CommunicationPlanById(id string) (response.CommunicationPlan, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	p, ok := c.communicationPlansById[id]
+	return p, ok
+}
+
+// RatePlans 获取全部资费计划，按名称排序
+func (c *PlanCatalog) RatePlans() []response.RatePlan {
+	c.mu.RLock()
+	plans := make([]response.RatePlan, 0, len(c.ratePlansByName))
+	for _, p := range c.ratePlansByName {
+		plans = append(plans, p)
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(plans, func(i, j int) bool { return plans[i].RatePlanName < plans[j].RatePlanName })
+	return plans
+}
+
+// CommunicationPlans 获取全部通信计划，按名称排序
+func (c *PlanCatalog) CommunicationPlans() []response.CommunicationPlan {
+	c.mu.RLock()
+	plans := make([]response.CommunicationPlan, 0, len(c.communicationPlansByName))
+	for _, p := range c.communicationPlansByName {
+		plans = append(plans, p)
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].CommunicationPlanName < plans[j].CommunicationPlanName
+	})
+	return plans
+}
+
+// RefreshedAt 获取最近一次成功刷新的时间，从未刷新时为零值
+func (c *PlanCatalog) RefreshedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refreshedAt
+}
+
+// CheckEditTerminal 检查变更资费计划或通信计划时目标计划在目录中存在
+//
+// 其他变更类型不做检查；目录从未刷新时返回错误，避免把空目录当作全部不存在。
+func (c *PlanCatalog) CheckEditTerminal(r *EditTerminalRequest) error {
+	if r.ChangeType != ChangeTypeRatePlan && r.ChangeType != ChangeTypeCommunicationPlan {
+		return nil
+	}
+	if c.RefreshedAt().IsZero() {
+		return api.NewApiRuleException("计划目录尚未加载，请先调用 Refresh", api.ERR_CODE_INVALID, nil)
+	}
+
+	ex := newRuleException()
+	switch r.ChangeType {
+	case ChangeTypeRatePlan:
+		if _, ok := c.RatePlanByName(r.TargetValue); !ok {
+			ex.AddViolation("TargetValue", "资费计划不存在: "+r.TargetValue)
+		}
+	case ChangeTypeCommunicationPlan:
+		if _, ok := c.CommunicationPlanByName(r.TargetValue); !ok {
+			ex.AddViolation("TargetValue", "通信计划不存在: "+r.TargetValue)
+		}
+	}
+	return ex.ErrOrNil()
+}
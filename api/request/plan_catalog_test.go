@@ -0,0 +1,132 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// ratePlansPage 构造一页资费计划，名称依次为 rate-first、rate-first+1...
+func ratePlansPage(first, count int, lastPage bool) string {
+	plans := make([]string, 0, count)
+	for i := first; i < first+count; i++ {
+		plans = append(plans, fmt.Sprintf(`{"ratePlanId":%d,"ratePlanName":"rate-%d"}`, 1000+i, i))
+	}
+	return fmt.Sprintf(`{"ratePlans":[%s],"lastPage":%t}`, strings.Join(plans, ","), lastPage)
+}
+
+// communicationPlansPage 构造一页通信计划，名称依次为 comm-first、comm-first+1...
+func communicationPlansPage(first, count int, lastPage bool) string {
+	plans := make([]string, 0, count)
+	for i := first; i < first+count; i++ {
+		plans = append(plans, fmt.Sprintf(`{"communicationPlanId":"%d","communicationPlanName":"comm-%d"}`, 2000+i, i))
+	}
+	return fmt.Sprintf(`{"communicationPlans":[%s],"lastPage":%t}`, strings.Join(plans, ","), lastPage)
+}
+
+// newPlanServer 创建返回两页资费计划与一页通信计划的模拟网关，endless 为 true 时资费计划永远不是最后一页
+func newPlanServer(t *testing.T, endless *int32) *scriptedServer {
+	return newScriptedServer(t, func(call gatewayCall) string {
+		if strings.Contains(call.Path, API_COMMUNICATION_PLANS) {
+			return communicationPlansPage(0, 2, true)
+		}
+		if atomic.LoadInt32(endless) == 1 || call.Data["pageNumber"] == "1" {
+			return ratePlansPage(0, MaxPlanPageSize, false)
+		}
+		return ratePlansPage(MaxPlanPageSize, 1, false)
+	})
+}
+
+func TestPlanCatalogRefreshLoadsAllPages(t *testing.T) {
+	var endless int32
+	srv := newPlanServer(t, &endless)
+	catalog := NewPlanCatalog(srv.client())
+
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if catalog.RefreshedAt().IsZero() {
+		t.Error("刷新成功后 RefreshedAt 不应为零值")
+	}
+	if n := len(catalog.RatePlans()); n != MaxPlanPageSize+1 {
+		t.Errorf("资费计划 %d 个, want %d", n, MaxPlanPageSize+1)
+	}
+	if n := len(catalog.CommunicationPlans()); n != 2 {
+		t.Errorf("通信计划 %d 个, want 2", n)
+	}
+
+	// 第二页的资费计划可按名称和ID查找
+	if p, ok := catalog.RatePlanByName(fmt.Sprintf("rate-%d", MaxPlanPageSize)); !ok || p.RatePlanId.String() != fmt.Sprint(1000+MaxPlanPageSize) {
+		t.Errorf("RatePlanByName() = %+v, %v", p, ok)
+	}
+	if p, ok := catalog.CommunicationPlanById("2001"); !ok || p.CommunicationPlanName != "comm-1" {
+		t.Errorf("CommunicationPlanById() = %+v, %v", p, ok)
+	}
+
+	var ratePages []interface{}
+	for _, call := range srv.Calls() {
+		if strings.Contains(call.Path, API_RATE_PLANS) {
+			ratePages = append(ratePages, call.Data["pageNumber"])
+		}
+	}
+	if fmt.Sprint(ratePages) != "[1 2]" {
+		t.Errorf("资费计划请求的页码 = %v, want [1 2]", ratePages)
+	}
+}
+
+func TestPlanCatalogRefreshPageLimitKeepsCatalog(t *testing.T) {
+	var endless int32
+	srv := newPlanServer(t, &endless)
+	catalog := NewPlanCatalog(srv.client())
+
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	refreshedAt := catalog.RefreshedAt()
+
+	atomic.StoreInt32(&endless, 1)
+	if err := catalog.Refresh(context.Background()); !errors.Is(err, ErrPageLimitExceeded) {
+		t.Fatalf("Refresh() error = %v, want ErrPageLimitExceeded", err)
+	}
+	if n := len(catalog.RatePlans()); n != MaxPlanPageSize+1 {
+		t.Errorf("刷新失败后资费计划 %d 个, want 保留原目录的 %d 个", n, MaxPlanPageSize+1)
+	}
+	if !catalog.RefreshedAt().Equal(refreshedAt) {
+		t.Error("刷新失败不应更新 RefreshedAt")
+	}
+}
+
+func TestPlanCatalogCheckEditTerminal(t *testing.T) {
+	var endless int32
+	catalog := NewPlanCatalog(newPlanServer(t, &endless).client())
+
+	if err := catalog.CheckEditTerminal(NewEditTerminalRequest(testIccid, ChangeTypeRatePlan, "rate-1")); err == nil {
+		t.Error("目录未加载时应返回错误")
+	}
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		req     *EditTerminalRequest
+		wantErr bool
+	}{
+		{"已知资费计划", NewEditTerminalRequest(testIccid, ChangeTypeRatePlan, "rate-1"), false},
+		{"未知资费计划", NewEditTerminalRequest(testIccid, ChangeTypeRatePlan, "rate-unknown"), true},
+		{"资费计划需按名称", NewEditTerminalRequest(testIccid, ChangeTypeRatePlan, "1001"), true},
+		{"已知通信计划", NewEditTerminalRequest(testIccid, ChangeTypeCommunicationPlan, "comm-0"), false},
+		{"未知通信计划", NewEditTerminalRequest(testIccid, ChangeTypeCommunicationPlan, "rate-1"), true},
+		{"其他变更类型不检查", NewChangeSimStatusRequest(testIccid, SimStatusActivated), false},
+	}
+
+	for _, tt := range tests {
+		err := catalog.CheckEditTerminal(tt.req)
+		if got := hasViolation(err, "TargetValue"); got != tt.wantErr {
+			t.Errorf("%s: CheckEditTerminal() error = %v, want TargetValue 违规 = %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
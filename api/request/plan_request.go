@@ -0,0 +1,105 @@
+package request
+
+import (
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 资费计划列表接口
+	API_RATE_PLANS     = "wsGetRatePlans/V1/1Main"
+	API_VER_RATE_PLANS = "V1.1"
+
+	// 通信计划列表接口
+	API_COMMUNICATION_PLANS     = "wsGetCommunicationPlans/V1/1Main"
+	API_VER_COMMUNICATION_PLANS = "V1.1"
+
+	// MaxPlanPageSize 计划列表接口单页的最大条数
+	MaxPlanPageSize = 50
+)
+
+// RatePlansRequest 查询账户可用资费计划列表请求（wsGetRatePlans）
+type RatePlansRequest struct {
+	CommonJsonRequest
+	pagination
+	MessageId string
+	Version   string
+}
+
+// NewRatePlansRequest 创建一个新的查询资费计划列表请求
+func NewRatePlansRequest() *RatePlansRequest {
+	r := &RatePlansRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_RATE_PLANS,
+	}
+	r.SetApiName(API_RATE_PLANS)
+	r.SetApiVer(API_VER_RATE_PLANS)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *RatePlansRequest) GetParams() map[string]interface{} {
+	pageSize, pageNumber := r.pageParams()
+	return r.buildParams(map[string]interface{}{
+		"messageId":  r.MessageId,
+		"version":    r.Version,
+		"pageSize":   pageSize,
+		"pageNumber": pageNumber,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *RatePlansRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.RatePlansResponse{}
+}
+
+// Check 客户端参数检查
+func (r *RatePlansRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	r.checkPage(ex, MaxPlanPageSize)
+	return ex.ErrOrNil()
+}
+
+// CommunicationPlansRequest 查询账户可用通信计划列表请求（wsGetCommunicationPlans）
+type CommunicationPlansRequest struct {
+	CommonJsonRequest
+	pagination
+	MessageId string
+	Version   string
+}
+
+// NewCommunicationPlansRequest 创建一个新的查询通信计划列表请求
+func NewCommunicationPlansRequest() *CommunicationPlansRequest {
+	r := &CommunicationPlansRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_COMMUNICATION_PLANS,
+	}
+	r.SetApiName(API_COMMUNICATION_PLANS)
+	r.SetApiVer(API_VER_COMMUNICATION_PLANS)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *CommunicationPlansRequest) GetParams() map[string]interface{} {
+	pageSize, pageNumber := r.pageParams()
+	return r.buildParams(map[string]interface{}{
+		"messageId":  r.MessageId,
+		"version":    r.Version,
+		"pageSize":   pageSize,
+		"pageNumber": pageNumber,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *CommunicationPlansRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.CommunicationPlansResponse{}
+}
+
+// Check 客户端参数检查
+func (r *CommunicationPlansRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	r.checkPage(ex, MaxPlanPageSize)
+	return ex.ErrOrNil()
+}
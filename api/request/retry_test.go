@@ -0,0 +1,58 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+func TestReadTimeoutDoesNotRepeatMutatingRequests(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   api.IoTGatewayRequest
+		wantCalls int32
+	}{
+		{"发送短信", NewSendSmsRequest("89860625680009634556", "hello"), 1},
+		{"修改终端状态", NewChangeSimStatusRequest("89860625680009634556", SimStatusDeactivated), 1},
+		{"修改网络接入配置", newValidEditNetworkAccessConfigRequest(), 1},
+		{"查询终端详情", NewTerminalDetailsRequest("89860625680009634556"), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(200 * time.Millisecond)
+				w.Write([]byte(`{"status":"0000","message":"成功","data":{}}`))
+			}))
+			defer srv.Close()
+
+			client := api.NewIoTGatewayClient(srv.URL, "app", "secret", "open")
+			client.SetReadTimeout(50)
+			client.SetRetryCount(2)
+			client.SetRetryPolicy(&api.ExponentialBackoffRetryPolicy{BaseDelay: time.Millisecond, Multiplier: 1})
+
+			_, err := client.Execute(tt.request)
+			var timeoutErr *api.TimeoutError
+			if !errors.As(err, &timeoutErr) {
+				t.Fatalf("Execute() error = %v, want TimeoutError", err)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("网关收到 %d 次请求, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// newValidEditNetworkAccessConfigRequest 创建通过校验的编辑网络接入配置请求
+func newValidEditNetworkAccessConfigRequest() *EditNetworkAccessConfigRequest {
+	r := NewEditNetworkAccessConfigRequest("1001")
+	r.RoamingRestriction = RoamingHomeOnly
+	return r
+}
@@ -0,0 +1,236 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 发送短信接口
+	API_SEND_SMS     = "wsSendSMS/V1/1Main"
+	API_VER_SEND_SMS = "V1.1"
+
+	// MaxSmsTpvp 短信有效期（TP-VP）的最大取值
+	MaxSmsTpvp = 255
+)
+
+// SmsMessageClass 短信类别
+type SmsMessageClass string
+
+const (
+	SmsMessageClassDefault SmsMessageClass = ""  // 使用网关默认值
+	SmsMessageClass0       SmsMessageClass = "0" // 闪信，直接显示不存储
+	SmsMessageClass1       SmsMessageClass = "1" // 存储到终端
+	SmsMessageClass2       SmsMessageClass = "2" // 存储到SIM卡
+	SmsMessageClass3       SmsMessageClass = "3" // 存储到外部设备
+)
+
+// smsMessageClasses 支持的短信类别
+var smsMessageClasses = map[SmsMessageClass]bool{
+	SmsMessageClassDefault: true,
+	SmsMessageClass0:       true,
+	SmsMessageClass1:       true,
+	SmsMessageClass2:       true,
+	SmsMessageClass3:       true,
+}
+
+// msisdnPattern MSISDN格式：可带国家码的5~15位数字
+var msisdnPattern = regexp.MustCompile(`^\+?[0-9]{5,15}$`)
+
+// IsMSISDN 判断是否为MSISDN格式
+func IsMSISDN(s string) bool {
+	return msisdnPattern.MatchString(s)
+}
+
+// SendSmsRequest 发送短信请求（wsSendSMS），目标为ICCID或MSISDN二选一
+type SendSmsRequest struct {
+	CommonJsonRequest
+	MessageId   string
+	Version     string
+	Iccid       string
+	Msisdn      string
+	MessageText string
+	// Encoding 短信编码，为空时根据内容自动选择
+	Encoding SmsEncoding
+	// Tpvp 短信有效期（TP-VP，0~255），为nil时使用网关默认值
+	Tpvp         *int
+	MessageClass SmsMessageClass
+}
+
+// NewSendSmsRequest 创建一个向ICCID发送短信的请求
+func NewSendSmsRequest(iccid, text string) *SendSmsRequest {
+	r := newSendSmsRequest(text)
+	r.Iccid = iccid
+	return r
+}
+
+// NewSendSmsToMsisdnRequest 创建一个向MSISDN发送短信的请求
+func NewSendSmsToMsisdnRequest(msisdn, text string) *SendSmsRequest {
+	r := newSendSmsRequest(text)
+	r.Msisdn = msisdn
+	return r
+}
+
+// newSendSmsRequest 创建未指定目标的发送短信请求
+func newSendSmsRequest(text string) *SendSmsRequest {
+	r := &SendSmsRequest{
+		CommonJsonRequest: *NewCommonJsonRequest(),
+		Version:           API_VER_SEND_SMS,
+		MessageText:       text,
+	}
+	r.SetApiName(API_SEND_SMS)
+	r.SetApiVer(API_VER_SEND_SMS)
+	return r
+}
+
+// SetTpvp 设置短信有效期（TP-VP）
+func (r *SendSmsRequest) SetTpvp(tpvp int) {
+	r.Tpvp = &tpvp
+}
+
+// GetEncoding 获取实际使用的短信编码，未指定时根据内容自动选择
+func (r *SendSmsRequest) GetEncoding() SmsEncoding {
+	if r.Encoding == SmsEncodingAuto {
+		return DetectSmsEncoding(r.MessageText)
+	}
+	return r.Encoding
+}
+
+// Split 按编码将长短信拆分为多个请求，每个请求发送一段独立短信；单条能发完时只返回自身
+//
+// 拆分后的请求复制原请求的全部设置（API名称与版本、目标、编码、有效期、类别、自定义参数、
+// DisableDefaultData 等）；MessageId 与调用方指定的交易ID每条短信各不相同，不复制。
+func (r *SendSmsRequest) Split() []*SendSmsRequest {
+	encoding := r.GetEncoding()
+	segments := SplitSms(r.MessageText, encoding)
+	if len(segments) <= 1 {
+		return []*SendSmsRequest{r}
+	}
+
+	requests := make([]*SendSmsRequest, len(segments))
+	for i, segment := range segments {
+		cp := *r
+		cp.Params = make(map[string]interface{}, len(r.Params))
+		for k, v := range r.Params {
+			cp.Params[k] = v
+		}
+		if r.Tpvp != nil {
+			tpvp := *r.Tpvp
+			cp.Tpvp = &tpvp
+		}
+		cp.MessageText = segment
+		cp.Encoding = encoding
+		cp.MessageId = ""
+		cp.SetTransId("")
+		cp.SetLastTransId("")
+		cp.SetReqText("")
+		requests[i] = &cp
+	}
+	return requests
+}
+
+// GetParams 获取请求参数
+func (r *SendSmsRequest) GetParams() map[string]interface{} {
+	var tpvp string
+	if r.Tpvp != nil {
+		tpvp = strconv.Itoa(*r.Tpvp)
+	}
+
+	return r.buildParams(map[string]interface{}{
+		"messageId":       r.MessageId,
+		"version":         r.Version,
+		"sentToIccid":     r.Iccid,
+		"sentToMsisdn":    r.Msisdn,
+		"messageText":     r.MessageText,
+		"messageEncoding": string(r.GetEncoding()),
+		"tpvp":            tpvp,
+		"messageClass":    string(r.MessageClass),
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *SendSmsRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.SendSmsResponse{}
+}
+
+// Check 客户端参数检查，短信长度不能超过所用编码的单条上限，超长短信通过 SendSms 自动拆分发送
+func (r *SendSmsRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+
+	switch {
+	case r.Iccid == "" && r.Msisdn == "":
+		ex.AddViolation("Iccid", "ICCID与MSISDN必须指定一个")
+	case r.Iccid != "" && r.Msisdn != "":
+		ex.AddViolation("Iccid", "ICCID与MSISDN只能指定一个")
+	case r.Iccid != "" && !IsICCID(r.Iccid):
+		ex.AddViolation("Iccid", "ICCID格式不正确: "+r.Iccid)
+	case r.Msisdn != "" && !IsMSISDN(r.Msisdn):
+		ex.AddViolation("Msisdn", "MSISDN格式不正确: "+r.Msisdn)
+	}
+
+	r.checkMessageText(ex)
+
+	if r.Tpvp != nil && (*r.Tpvp < 0 || *r.Tpvp > MaxSmsTpvp) {
+		ex.AddViolation("Tpvp", fmt.Sprintf("取值范围为0~%d，实际%d", MaxSmsTpvp, *r.Tpvp))
+	}
+
+	if !smsMessageClasses[r.MessageClass] {
+		ex.AddViolation("MessageClass", "不支持的短信类别: "+string(r.MessageClass))
+	}
+
+	return ex.ErrOrNil()
+}
+
+// checkMessageText 检查短信内容与编码
+func (r *SendSmsRequest) checkMessageText(ex *api.ApiRuleException) {
+	if r.MessageText == "" {
+		ex.AddViolation("MessageText", "不能为空")
+		return
+	}
+
+	limit := MaxGSM7SingleLength
+	switch r.GetEncoding() {
+	case SmsEncodingGSM7:
+		if !IsGSM7(r.MessageText) {
+			ex.AddViolation("Encoding", "短信内容包含GSM-7字母表以外的字符，请使用UCS2编码")
+			return
+		}
+	case SmsEncodingUCS2:
+		limit = MaxUCS2SingleLength
+	default:
+		ex.AddViolation("Encoding", "不支持的短信编码: "+string(r.Encoding))
+		return
+	}
+
+	if length := SmsLength(r.MessageText, r.GetEncoding()); length > limit {
+		ex.AddViolation("MessageText", fmt.Sprintf("%s编码单条最多%d个字符，实际%d个，请使用 SendSms 自动拆分发送",
+			r.GetEncoding(), limit, length))
+	}
+}
+
+// SendSms 发送短信，超过单条上限时自动拆分为多条依次发送，返回每条的响应
+//
+// 网关接口不支持级联短信头（UDH），拆分后的各条在终端上是互不关联的独立短信，到达顺序不保证。
+// 某一条发送失败时停止发送，返回已发送成功的响应与错误。
+func SendSms(ctx context.Context, client api.IoTGatewayClient, req *SendSmsRequest) ([]*response.SendSmsResponse, error) {
+	parts := req.Split()
+	responses := make([]*response.SendSmsResponse, 0, len(parts))
+	for _, part := range parts {
+		resp, err := executeSuccessful(ctx, client, part)
+		if err != nil {
+			return responses, err
+		}
+		sendResp, ok := resp.(*response.SendSmsResponse)
+		if !ok {
+			return responses, fmt.Errorf("发送短信响应类型不正确: %T", resp)
+		}
+		responses = append(responses, sendResp)
+	}
+	return responses, nil
+}
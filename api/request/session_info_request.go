@@ -0,0 +1,57 @@
+package request
+
+import (
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 会话信息接口
+	API_SESSION_INFO     = "wsGetSessionInfo/V1/1Main"
+	API_VER_SESSION_INFO = "V1.1"
+
+	// MaxSessionInfoIccids 会话信息接口单次查询的最大ICCID数量
+	MaxSessionInfoIccids = 50
+)
+
+// SessionInfoRequest 查询终端当前或最近一次数据会话请求（wsGetSessionInfo），可用于判断终端是否在线
+type SessionInfoRequest struct {
+	CommonJsonRequest
+	MessageId string
+	Version   string
+	Iccids    []string
+}
+
+// NewSessionInfoRequest 创建一个新的查询会话信息请求
+func NewSessionInfoRequest(iccids ...string) *SessionInfoRequest {
+	r := &SessionInfoRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_SESSION_INFO,
+		Iccids:            iccids,
+	}
+	r.SetApiName(API_SESSION_INFO)
+	r.SetApiVer(API_VER_SESSION_INFO)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *SessionInfoRequest) GetParams() map[string]interface{} {
+	return r.buildParams(map[string]interface{}{
+		"messageId": r.MessageId,
+		"version":   r.Version,
+		"iccids":    r.Iccids,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *SessionInfoRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.SessionInfoResponse{}
+}
+
+// Check 客户端参数检查，ICCID数量不能超过 MaxSessionInfoIccids
+func (r *SessionInfoRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	checkIccids(ex, "Iccids", r.Iccids, MaxSessionInfoIccids)
+	return ex.ErrOrNil()
+}
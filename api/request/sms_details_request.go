@@ -0,0 +1,70 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 短信详情接口
+	API_SMS_DETAILS     = "wsGetSMSDetails/V1/1Main"
+	API_VER_SMS_DETAILS = "V1.1"
+
+	// MaxSmsDetailsIds 短信详情接口单次查询的最大短信ID数量
+	MaxSmsDetailsIds = 50
+)
+
+// SmsDetailsRequest 查询短信详情请求（wsGetSMSDetails），可查询下行与上行短信的状态
+type SmsDetailsRequest struct {
+	CommonJsonRequest
+	MessageId string
+	Version   string
+	SmsMsgIds []string
+}
+
+// NewSmsDetailsRequest 创建一个新的查询短信详情请求
+func NewSmsDetailsRequest(smsMsgIds ...string) *SmsDetailsRequest {
+	r := &SmsDetailsRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_SMS_DETAILS,
+		SmsMsgIds:         smsMsgIds,
+	}
+	r.SetApiName(API_SMS_DETAILS)
+	r.SetApiVer(API_VER_SMS_DETAILS)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *SmsDetailsRequest) GetParams() map[string]interface{} {
+	return r.buildParams(map[string]interface{}{
+		"messageId": r.MessageId,
+		"version":   r.Version,
+		"smsMsgIds": r.SmsMsgIds,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *SmsDetailsRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.SmsDetailsResponse{}
+}
+
+// Check 客户端参数检查，短信ID数量不能超过 MaxSmsDetailsIds
+func (r *SmsDetailsRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+
+	if len(r.SmsMsgIds) == 0 {
+		ex.AddViolation("SmsMsgIds", "不能为空")
+	} else if len(r.SmsMsgIds) > MaxSmsDetailsIds {
+		ex.AddViolation("SmsMsgIds", fmt.Sprintf("单次最多%d个，实际%d个", MaxSmsDetailsIds, len(r.SmsMsgIds)))
+	}
+	for i, id := range r.SmsMsgIds {
+		if id == "" {
+			ex.AddViolation(fmt.Sprintf("SmsMsgIds[%d]", i), "不能为空")
+		}
+	}
+
+	return ex.ErrOrNil()
+}
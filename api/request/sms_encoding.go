@@ -0,0 +1,122 @@
+package request
+
+import (
+	"unicode/utf16"
+)
+
+// SmsEncoding 短信编码
+type SmsEncoding string
+
+const (
+	SmsEncodingAuto SmsEncoding = ""     // 根据内容自动选择
+	SmsEncodingGSM7 SmsEncoding = "GSM7" // GSM 7位默认字母表
+	SmsEncodingUCS2 SmsEncoding = "UCS2" // UCS-2，用于中文等字符
+)
+
+const (
+	// 单条短信长度上限，长短信拆分后每段同样按此上限
+	MaxGSM7SingleLength = 160
+	MaxUCS2SingleLength = 70
+)
+
+// gsm7Basic GSM 03.38 默认字母表
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extension GSM 03.38 扩展字符，每个占2个字符位
+const gsm7Extension = "\f^{}\\[~]|€"
+
+var (
+	gsm7BasicSet     = runeSet(gsm7Basic)
+	gsm7ExtensionSet = runeSet(gsm7Extension)
+)
+
+// runeSet 将字符串转换为字符集合
+func runeSet(s string) map[rune]bool {
+	set := make(map[rune]bool)
+	for _, r := range s {
+		set[r] = true
+	}
+	return set
+}
+
+// IsGSM7 判断文本是否可以完全使用GSM 7位字母表编码
+func IsGSM7(text string) bool {
+	for _, r := range text {
+		if !gsm7BasicSet[r] && !gsm7ExtensionSet[r] {
+			return false
+		}
+	}
+	return true
+}
+
+// DetectSmsEncoding 根据内容选择短信编码
+func DetectSmsEncoding(text string) SmsEncoding {
+	if IsGSM7(text) {
+		return SmsEncodingGSM7
+	}
+	return SmsEncodingUCS2
+}
+
+// SmsLength 按编码计算短信长度：GSM7按字符位计算（扩展字符占2位），UCS2按UTF-16码元计算
+func SmsLength(text string, encoding SmsEncoding) int {
+	if encoding == SmsEncodingAuto {
+		encoding = DetectSmsEncoding(text)
+	}
+
+	length := 0
+	for _, r := range text {
+		length += runeLength(r, encoding)
+	}
+	return length
+}
+
+// SplitSms 按编码拆分长短信，单条能发完时只返回一段
+//
+// 网关接口不支持级联短信头（UDH），每段按单条上限拆分，发送后是互不关联的独立短信。
+func SplitSms(text string, encoding SmsEncoding) []string {
+	if encoding == SmsEncodingAuto {
+		encoding = DetectSmsEncoding(text)
+	}
+
+	segment := MaxGSM7SingleLength
+	if encoding == SmsEncodingUCS2 {
+		segment = MaxUCS2SingleLength
+	}
+	if SmsLength(text, encoding) <= segment {
+		return []string{text}
+	}
+
+	var segments []string
+	var current []rune
+	currentLength := 0
+	for _, r := range text {
+		n := runeLength(r, encoding)
+		// 扩展字符与代理对不能跨段拆分
+		if currentLength+n > segment {
+			segments = append(segments, string(current))
+			current = current[:0]
+			currentLength = 0
+		}
+		current = append(current, r)
+		currentLength += n
+	}
+	if len(current) > 0 {
+		segments = append(segments, string(current))
+	}
+	return segments
+}
+
+// runeLength 单个字符在指定编码下占用的长度
+func runeLength(r rune, encoding SmsEncoding) int {
+	if encoding == SmsEncodingUCS2 {
+		if utf16.IsSurrogate(r) || r > 0xFFFF {
+			return 2
+		}
+		return 1
+	}
+	if gsm7ExtensionSet[r] {
+		return 2
+	}
+	return 1
+}
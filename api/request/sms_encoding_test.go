@@ -0,0 +1,142 @@
+package request
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+func TestSmsLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		encoding SmsEncoding
+		want     int
+		wantEnc  SmsEncoding
+	}{
+		{"GSM7基本字符", "Hello", SmsEncodingAuto, 5, SmsEncodingGSM7},
+		{"GSM7扩展字符占2位", "€{}", SmsEncodingGSM7, 6, SmsEncodingGSM7},
+		{"中文使用UCS2", "设备唤醒", SmsEncodingAuto, 4, SmsEncodingUCS2},
+		{"代理对占2个码元", "😀", SmsEncodingUCS2, 2, SmsEncodingUCS2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SmsLength(tt.text, tt.encoding); got != tt.want {
+				t.Errorf("SmsLength(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+			if got := DetectSmsEncoding(tt.text); got != tt.wantEnc {
+				t.Errorf("DetectSmsEncoding(%q) = %s, want %s", tt.text, got, tt.wantEnc)
+			}
+		})
+	}
+}
+
+func TestSplitSms(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		encoding     SmsEncoding
+		wantSegments []int
+	}{
+		{"GSM7单条上限不拆分", strings.Repeat("a", 160), SmsEncodingGSM7, []int{160}},
+		{"GSM7超出一位拆为两条", strings.Repeat("a", 161), SmsEncodingGSM7, []int{160, 1}},
+		{"GSM7扩展字符不跨段", strings.Repeat("a", 159) + "€", SmsEncodingGSM7, []int{159, 2}},
+		{"UCS2单条上限不拆分", strings.Repeat("中", 70), SmsEncodingUCS2, []int{70}},
+		{"UCS2按70拆分", strings.Repeat("中", 150), SmsEncodingUCS2, []int{70, 70, 10}},
+		{"代理对不跨段", strings.Repeat("中", 69) + "😀", SmsEncodingUCS2, []int{69, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments := SplitSms(tt.text, tt.encoding)
+			if len(segments) != len(tt.wantSegments) {
+				t.Fatalf("SplitSms() 拆分为 %d 段, want %d", len(segments), len(tt.wantSegments))
+			}
+			if strings.Join(segments, "") != tt.text {
+				t.Error("拆分后的内容拼接后与原文不一致")
+			}
+			for i, segment := range segments {
+				if got := SmsLength(segment, tt.encoding); got != tt.wantSegments[i] {
+					t.Errorf("第%d段长度 = %d, want %d", i+1, got, tt.wantSegments[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSendSmsRequestCheckLength(t *testing.T) {
+	req := NewSendSmsRequest("89860625680009634556", strings.Repeat("中", 71))
+	if err := req.Check(); err == nil {
+		t.Error("超过单条上限的短信直接发送时 Check 应返回错误")
+	}
+
+	for i, part := range req.Split() {
+		if err := part.Check(); err != nil {
+			t.Errorf("拆分后的第%d条 Check() error = %v", i+1, err)
+		}
+	}
+}
+
+func TestSendSmsRequestSplitCopiesSettings(t *testing.T) {
+	req := NewSendSmsToMsisdnRequest("8613800000000", strings.Repeat("中", 100))
+	req.SetApiType("JSON")
+	req.SetTpvp(167)
+	req.MessageClass = SmsMessageClass1
+	req.MessageId = "msg-1"
+	req.DisableDefaultData = true
+	req.Params["custom"] = "v"
+	req.SetTransId("20240101120000000000001")
+
+	parts := req.Split()
+	if len(parts) != 2 {
+		t.Fatalf("Split() 返回 %d 条, want 2", len(parts))
+	}
+	for i, part := range parts {
+		if !part.IsDefaultDataDisabled() {
+			t.Errorf("第%d条未保留 DisableDefaultData", i+1)
+		}
+		if part.Msisdn != req.Msisdn || part.GetApiName() != req.GetApiName() || part.GetApiType() != "JSON" {
+			t.Errorf("第%d条未保留目标或API设置: %+v", i+1, part)
+		}
+		if part.Tpvp == nil || *part.Tpvp != 167 || part.MessageClass != SmsMessageClass1 || part.Encoding != SmsEncodingUCS2 {
+			t.Errorf("第%d条未保留短信设置", i+1)
+		}
+		if part.Params["custom"] != "v" {
+			t.Errorf("第%d条未保留自定义参数", i+1)
+		}
+		if part.MessageId != "" || part.GetTransId() != "" {
+			t.Errorf("第%d条不应复制 MessageId 与交易ID", i+1)
+		}
+	}
+
+	parts[0].Params["custom"] = "changed"
+	*parts[0].Tpvp = 1
+	if req.Params["custom"] != "v" || *req.Tpvp != 167 || parts[1].Params["custom"] != "v" {
+		t.Error("拆分后的请求与原请求共享了可变字段")
+	}
+}
+
+func TestSendSmsSendsEveryPartWithoutDefaultData(t *testing.T) {
+	srv := newRecordingServer(t)
+	defer srv.Close()
+
+	client := api.NewIoTGatewayClient(srv.URL, "app", "secret", "open")
+	req := NewSendSmsRequest("89860625680009634556", strings.Repeat("中", 150))
+	req.DisableDefaultData = true
+
+	responses, err := SendSms(context.Background(), client, req)
+	if err != nil {
+		t.Fatalf("SendSms() error = %v", err)
+	}
+	if len(responses) != 3 || len(srv.transIDs) != 3 {
+		t.Fatalf("发送 %d 条、响应 %d 条, want 3", len(srv.transIDs), len(responses))
+	}
+	for i, messageID := range srv.messageIDs {
+		if messageID != "" {
+			t.Errorf("第%d条注入了 messageId %q", i+1, messageID)
+		}
+	}
+}
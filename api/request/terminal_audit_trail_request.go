@@ -0,0 +1,116 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 终端变更历史接口
+	API_TERMINAL_AUDIT_TRAIL     = "wsGetTerminalAuditTrail/V1/1Main"
+	API_VER_TERMINAL_AUDIT_TRAIL = "V1.1"
+
+	// MaxAuditTrailPageSize 终端变更历史接口单页的最大条数
+	MaxAuditTrailPageSize = 50
+
+	// MaxAuditTrailDaysOfHistory 终端变更历史最多可查询的天数
+	MaxAuditTrailDaysOfHistory = 365
+)
+
+// TerminalAuditTrailRequest 查询终端变更历史请求（wsGetTerminalAuditTrail）
+type TerminalAuditTrailRequest struct {
+	CommonJsonRequest
+	pagination
+	MessageId string
+	Version   string
+	Iccid     string
+	// DaysOfHistory 查询最近多少天的变更，0表示使用网关默认值
+	DaysOfHistory int
+}
+
+// NewTerminalAuditTrailRequest 创建一个新的查询终端变更历史请求
+func NewTerminalAuditTrailRequest(iccid string) *TerminalAuditTrailRequest {
+	r := &TerminalAuditTrailRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_TERMINAL_AUDIT_TRAIL,
+		Iccid:             iccid,
+	}
+	r.SetApiName(API_TERMINAL_AUDIT_TRAIL)
+	r.SetApiVer(API_VER_TERMINAL_AUDIT_TRAIL)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *TerminalAuditTrailRequest) GetParams() map[string]interface{} {
+	var daysOfHistory string
+	if r.DaysOfHistory > 0 {
+		daysOfHistory = strconv.Itoa(r.DaysOfHistory)
+	}
+
+	pageSize, pageNumber := r.pageParams()
+	return r.buildParams(map[string]interface{}{
+		"messageId":     r.MessageId,
+		"version":       r.Version,
+		"iccid":         r.Iccid,
+		"daysOfHistory": daysOfHistory,
+		"pageSize":      pageSize,
+		"pageNumber":    pageNumber,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *TerminalAuditTrailRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.TerminalAuditTrailResponse{}
+}
+
+// Check 客户端参数检查
+func (r *TerminalAuditTrailRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	r.checkPage(ex, MaxAuditTrailPageSize)
+
+	if !IsICCID(r.Iccid) {
+		ex.AddViolation("Iccid", "ICCID格式不正确: "+r.Iccid)
+	}
+	if r.DaysOfHistory < 0 || r.DaysOfHistory > MaxAuditTrailDaysOfHistory {
+		ex.AddViolation("DaysOfHistory", fmt.Sprintf("取值范围为0~%d，实际%d", MaxAuditTrailDaysOfHistory, r.DaysOfHistory))
+	}
+
+	return ex.ErrOrNil()
+}
+
+// FetchTerminalAuditTrail 自动翻页获取一个ICCID的全部变更历史
+//
+// daysOfHistory 为0时使用网关默认的查询天数。翻页达到上限仍未取完时返回已获取的记录与 ErrPageLimitExceeded。
+func FetchTerminalAuditTrail(ctx context.Context, client api.IoTGatewayClient, iccid string, daysOfHistory int) ([]response.AuditTrailEntry, error) {
+	var entries []response.AuditTrailEntry
+	for page := 1; ; page++ {
+		if page > maxListPages {
+			return entries, ErrPageLimitExceeded
+		}
+
+		req := NewTerminalAuditTrailRequest(iccid)
+		req.DaysOfHistory = daysOfHistory
+		req.PageSize = MaxAuditTrailPageSize
+		req.PageNumber = page
+
+		resp, err := executeSuccessful(ctx, client, req)
+		if err != nil {
+			return nil, err
+		}
+		auditResp, ok := resp.(*response.TerminalAuditTrailResponse)
+		if !ok {
+			return nil, fmt.Errorf("终端变更历史响应类型不正确: %T", resp)
+		}
+
+		entries = append(entries, auditResp.GetEntries()...)
+		if auditResp.IsLastPage() || len(auditResp.GetEntries()) < MaxAuditTrailPageSize {
+			break
+		}
+	}
+	return entries, nil
+}
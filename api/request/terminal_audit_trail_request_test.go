@@ -0,0 +1,77 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// auditTrailPage 构造一页变更记录，value 依次为 first、first+1...
+func auditTrailPage(first, count int, lastPage bool) string {
+	entries := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		entries = append(entries, fmt.Sprintf(`{"field":"SIM_STATUS","value":"%d","dateChanged":"2024-03-01 08:00:00"}`, first+i))
+	}
+	return fmt.Sprintf(`{"terminalAuditTrail":[%s],"lastPage":%t}`, strings.Join(entries, ","), lastPage)
+}
+
+func TestFetchTerminalAuditTrailPagesToLastPage(t *testing.T) {
+	srv := newScriptedServer(t, func(call gatewayCall) string {
+		// 第3页标记为最后一页，即使条数已满也不再翻页
+		switch call.Data["pageNumber"] {
+		case "1":
+			return auditTrailPage(0, MaxAuditTrailPageSize, false)
+		case "2":
+			return auditTrailPage(MaxAuditTrailPageSize, MaxAuditTrailPageSize, false)
+		}
+		return auditTrailPage(2*MaxAuditTrailPageSize, MaxAuditTrailPageSize, true)
+	})
+
+	entries, err := FetchTerminalAuditTrail(context.Background(), srv.client(), testIccid, 30)
+	if err != nil {
+		t.Fatalf("FetchTerminalAuditTrail() error = %v", err)
+	}
+	if len(entries) != 3*MaxAuditTrailPageSize {
+		t.Fatalf("获取到 %d 条记录, want %d", len(entries), 3*MaxAuditTrailPageSize)
+	}
+	for i, e := range entries {
+		if e.Value != fmt.Sprint(i) {
+			t.Fatalf("第%d条记录 value = %s，记录顺序不正确", i, e.Value)
+		}
+	}
+
+	calls := srv.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("网关收到 %d 次请求, want 3", len(calls))
+	}
+	for i, call := range calls {
+		if got, want := call.Data["pageNumber"], fmt.Sprint(i+1); got != want {
+			t.Errorf("第%d次请求 pageNumber = %v, want %v", i+1, got, want)
+		}
+		if got := call.Data["iccid"]; got != testIccid {
+			t.Errorf("第%d次请求 iccid = %v, want %s", i+1, got, testIccid)
+		}
+		if got := call.Data["daysOfHistory"]; got != "30" {
+			t.Errorf("第%d次请求 daysOfHistory = %v, want 30", i+1, got)
+		}
+	}
+}
+
+func TestFetchTerminalAuditTrailPageLimit(t *testing.T) {
+	srv := newScriptedServer(t, func(call gatewayCall) string {
+		return auditTrailPage(0, MaxAuditTrailPageSize, false)
+	})
+
+	entries, err := FetchTerminalAuditTrail(context.Background(), srv.client(), testIccid, 0)
+	if !errors.Is(err, ErrPageLimitExceeded) {
+		t.Fatalf("FetchTerminalAuditTrail() error = %v, want ErrPageLimitExceeded", err)
+	}
+	if len(entries) != maxListPages*MaxAuditTrailPageSize {
+		t.Errorf("获取到 %d 条记录, want %d", len(entries), maxListPages*MaxAuditTrailPageSize)
+	}
+	if n := len(srv.Calls()); n != maxListPages {
+		t.Errorf("网关收到 %d 次请求, want %d", n, maxListPages)
+	}
+}
@@ -0,0 +1,57 @@
+package request
+
+import (
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 终端详情接口
+	API_TERMINAL_DETAILS     = "wsGetTerminalDetails/V1/1Main"
+	API_VER_TERMINAL_DETAILS = "V1.1"
+
+	// MaxTerminalDetailsIccids 终端详情接口单次查询的最大ICCID数量
+	MaxTerminalDetailsIccids = 50
+)
+
+// TerminalDetailsRequest 查询终端详情请求（wsGetTerminalDetails）
+type TerminalDetailsRequest struct {
+	CommonJsonRequest
+	MessageId string
+	Version   string
+	Iccids    []string
+}
+
+// NewTerminalDetailsRequest 创建一个新的查询终端详情请求
+func NewTerminalDetailsRequest(iccids ...string) *TerminalDetailsRequest {
+	r := &TerminalDetailsRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_TERMINAL_DETAILS,
+		Iccids:            iccids,
+	}
+	r.SetApiName(API_TERMINAL_DETAILS)
+	r.SetApiVer(API_VER_TERMINAL_DETAILS)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *TerminalDetailsRequest) GetParams() map[string]interface{} {
+	return r.buildParams(map[string]interface{}{
+		"messageId": r.MessageId,
+		"version":   r.Version,
+		"iccids":    r.Iccids,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *TerminalDetailsRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.TerminalDetailsResponse{}
+}
+
+// Check 客户端参数检查，ICCID数量不能超过 MaxTerminalDetailsIccids
+func (r *TerminalDetailsRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	checkIccids(ex, "Iccids", r.Iccids, MaxTerminalDetailsIccids)
+	return ex.ErrOrNil()
+}
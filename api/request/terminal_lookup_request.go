@@ -0,0 +1,209 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 按MSISDN查询终端接口
+	API_TERMINALS_BY_MSISDN     = "wsGetTerminalsByMsisdn/V1/1Main"
+	API_VER_TERMINALS_BY_MSISDN = "V1.1"
+
+	// 按IMSI查询终端接口
+	API_TERMINALS_BY_IMSI     = "wsGetTerminalsByImsi/V1/1Main"
+	API_VER_TERMINALS_BY_IMSI = "V1.1"
+
+	// MaxTerminalLookupIds 按MSISDN或IMSI查询终端时单次的最大数量
+	MaxTerminalLookupIds = 50
+)
+
+// ErrTerminalNotFound 网关中没有与标识对应的终端
+var ErrTerminalNotFound = errors.New("未找到对应的终端")
+
+// imsiPattern IMSI格式：15位数字，MCC首位为2~7
+var imsiPattern = regexp.MustCompile(`^[2-7][0-9]{14}$`)
+
+// IsIMSI 判断是否为IMSI格式
+func IsIMSI(s string) bool {
+	return imsiPattern.MatchString(s)
+}
+
+// IdentifierType 终端标识类型
+type IdentifierType string
+
+const (
+	IdentifierUnknown IdentifierType = ""
+	IdentifierICCID   IdentifierType = "ICCID"
+	IdentifierIMSI    IdentifierType = "IMSI"
+	IdentifierMSISDN  IdentifierType = "MSISDN"
+)
+
+// DetectIdentifierType 判断终端标识的类型，依次按ICCID、IMSI、MSISDN匹配
+//
+// IMSI以MCC开头（首位2~7），带86国家码的15位MSISDN不会被识别为IMSI。
+// IMEI同为15位数字，无法按格式与IMSI、MSISDN区分，网关也没有按IMEI查询的接口，因此不单独识别。
+func DetectIdentifierType(identifier string) IdentifierType {
+	switch {
+	case IsICCID(identifier):
+		return IdentifierICCID
+	case IsIMSI(identifier):
+		return IdentifierIMSI
+	case IsMSISDN(identifier):
+		return IdentifierMSISDN
+	}
+	return IdentifierUnknown
+}
+
+// TerminalsByMsisdnRequest 按MSISDN查询终端请求（wsGetTerminalsByMsisdn）
+type TerminalsByMsisdnRequest struct {
+	CommonJsonRequest
+	MessageId string
+	Version   string
+	Msisdns   []string
+}
+
+// NewTerminalsByMsisdnRequest 创建一个新的按MSISDN查询终端请求
+func NewTerminalsByMsisdnRequest(msisdns ...string) *TerminalsByMsisdnRequest {
+	r := &TerminalsByMsisdnRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_TERMINALS_BY_MSISDN,
+		Msisdns:           msisdns,
+	}
+	r.SetApiName(API_TERMINALS_BY_MSISDN)
+	r.SetApiVer(API_VER_TERMINALS_BY_MSISDN)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *TerminalsByMsisdnRequest) GetParams() map[string]interface{} {
+	return r.buildParams(map[string]interface{}{
+		"messageId": r.MessageId,
+		"version":   r.Version,
+		"msisdns":   r.Msisdns,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *TerminalsByMsisdnRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.TerminalLookupResponse{}
+}
+
+// Check 客户端参数检查，MSISDN数量不能超过 MaxTerminalLookupIds
+func (r *TerminalsByMsisdnRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	checkLookupIds(ex, "Msisdns", r.Msisdns, IsMSISDN, "MSISDN")
+	return ex.ErrOrNil()
+}
+
+// TerminalsByImsiRequest 按IMSI查询终端请求（wsGetTerminalsByImsi）
+type TerminalsByImsiRequest struct {
+	CommonJsonRequest
+	MessageId string
+	Version   string
+	Imsis     []string
+}
+
+// NewTerminalsByImsiRequest 创建一个新的按IMSI查询终端请求
+func NewTerminalsByImsiRequest(imsis ...string) *TerminalsByImsiRequest {
+	r := &TerminalsByImsiRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_TERMINALS_BY_IMSI,
+		Imsis:             imsis,
+	}
+	r.SetApiName(API_TERMINALS_BY_IMSI)
+	r.SetApiVer(API_VER_TERMINALS_BY_IMSI)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *TerminalsByImsiRequest) GetParams() map[string]interface{} {
+	return r.buildParams(map[string]interface{}{
+		"messageId": r.MessageId,
+		"version":   r.Version,
+		"imsis":     r.Imsis,
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *TerminalsByImsiRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.TerminalLookupResponse{}
+}
+
+// Check 客户端参数检查，IMSI数量不能超过 MaxTerminalLookupIds
+func (r *TerminalsByImsiRequest) Check() error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+	checkLookupIds(ex, "Imsis", r.Imsis, IsIMSI, "IMSI")
+	return ex.ErrOrNil()
+}
+
+// checkLookupIds 检查查询标识列表不为空、数量不超过 MaxTerminalLookupIds 且格式正确
+func checkLookupIds(ex *api.ApiRuleException, field string, ids []string, valid func(string) bool, name string) {
+	if len(ids) == 0 {
+		ex.AddViolation(field, "不能为空")
+		return
+	}
+	if len(ids) > MaxTerminalLookupIds {
+		ex.AddViolation(field, fmt.Sprintf("单次最多%d个，实际%d个", MaxTerminalLookupIds, len(ids)))
+	}
+	for i, id := range ids {
+		if !valid(id) {
+			ex.AddViolation(fmt.Sprintf("%s[%d]", field, i), name+"格式不正确: "+id)
+		}
+	}
+}
+
+// TerminalResolver 根据ICCID、IMSI或MSISDN解析终端的ICCID
+type TerminalResolver struct {
+	client api.IoTGatewayClient
+}
+
+// NewTerminalResolver 创建一个通过 client 查询的终端解析器
+func NewTerminalResolver(client api.IoTGatewayClient) *TerminalResolver {
+	return &TerminalResolver{client: client}
+}
+
+// ResolveICCID 自动识别标识类型并返回对应的ICCID
+//
+// 标识为ICCID时直接返回，不访问网关；为IMSI或MSISDN时调用对应的查询接口。
+// 网关没有对应终端时返回的错误满足 errors.Is(err, ErrTerminalNotFound)。
+func (r *TerminalResolver) ResolveICCID(ctx context.Context, identifier string) (string, error) {
+	identifier = strings.TrimSpace(identifier)
+
+	var req api.IoTGatewayRequest
+	switch DetectIdentifierType(identifier) {
+	case IdentifierICCID:
+		return identifier, nil
+	case IdentifierIMSI:
+		req = NewTerminalsByImsiRequest(identifier)
+	case IdentifierMSISDN:
+		req = NewTerminalsByMsisdnRequest(identifier)
+	default:
+		ex := newRuleException()
+		ex.AddViolation("Identifier", "无法识别为ICCID、IMSI或MSISDN: "+identifier)
+		return "", ex
+	}
+
+	resp, err := executeSuccessful(ctx, r.client, req)
+	if err != nil {
+		return "", err
+	}
+	lookupResp, ok := resp.(*response.TerminalLookupResponse)
+	if !ok {
+		return "", fmt.Errorf("终端查询响应类型不正确: %T", resp)
+	}
+
+	iccids := lookupResp.GetIccids()
+	if len(iccids) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrTerminalNotFound, identifier)
+	}
+	return iccids[0], nil
+}
@@ -0,0 +1,105 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetectIdentifierType(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier string
+		want       IdentifierType
+	}{
+		{"20位ICCID", "89860625680009634556", IdentifierICCID},
+		{"19位ICCID", "8986062568000963455", IdentifierICCID},
+		{"带字母的ICCID", "89860625680009634F56", IdentifierICCID},
+		{"中国IMSI", "460011234567890", IdentifierIMSI},
+		{"北美IMSI", "310150123456789", IdentifierIMSI},
+		{"11位手机号", "13800138000", IdentifierMSISDN},
+		{"13位物联网号码", "1064912345678", IdentifierMSISDN},
+		{"带86的15位MSISDN", "861064912345678", IdentifierMSISDN},
+		{"带加号的MSISDN", "+8613800138000", IdentifierMSISDN},
+		// IMEI与IMSI、MSISDN同为15位数字，按IMSI、MSISDN的优先级识别
+		{"IMEI按IMSI识别", "356938035643809", IdentifierIMSI},
+		{"IMEI按MSISDN识别", "860000000000007", IdentifierMSISDN},
+		{"16位IMEISV", "3569380356438091", IdentifierUnknown},
+		{"非89开头的20位数字", "12345678901234567890", IdentifierUnknown},
+		{"18位的89开头数字", "898606256800096345", IdentifierUnknown},
+		{"过短", "1234", IdentifierUnknown},
+		{"含非法字符", "138-0013-8000", IdentifierUnknown},
+		{"空串", "", IdentifierUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := DetectIdentifierType(tt.identifier); got != tt.want {
+			t.Errorf("%s: DetectIdentifierType(%q) = %q, want %q", tt.name, tt.identifier, got, tt.want)
+		}
+	}
+}
+
+func TestResolveICCID(t *testing.T) {
+	srv := newScriptedServer(t, func(call gatewayCall) string {
+		switch {
+		case strings.Contains(call.Path, API_TERMINALS_BY_IMSI):
+			return `{"terminals":[{"iccid":"89860625680009634001","imsi":"460011234567890"}]}`
+		case strings.Contains(call.Path, API_TERMINALS_BY_MSISDN):
+			if msisdns, _ := call.Data["msisdns"].([]interface{}); len(msisdns) == 1 && msisdns[0] == "13800138000" {
+				return `{"terminals":[{"iccid":"89860625680009634002","msisdn":"13800138000"}]}`
+			}
+			return `{"terminals":[]}`
+		}
+		t.Errorf("意外的接口调用: %s", call.Path)
+		return `{}`
+	})
+	resolver := NewTerminalResolver(srv.client())
+
+	tests := []struct {
+		name       string
+		identifier string
+		want       string
+		wantCalls  int
+	}{
+		{"ICCID不访问网关", " 89860625680009634556 ", "89860625680009634556", 0},
+		{"按IMSI查询", "460011234567890", "89860625680009634001", 1},
+		{"按MSISDN查询", "13800138000", "89860625680009634002", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(srv.Calls())
+			got, err := resolver.ResolveICCID(context.Background(), tt.identifier)
+			if err != nil {
+				t.Fatalf("ResolveICCID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveICCID() = %q, want %q", got, tt.want)
+			}
+			if calls := len(srv.Calls()) - before; calls != tt.wantCalls {
+				t.Errorf("网关收到 %d 次请求, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestResolveICCIDErrors(t *testing.T) {
+	srv := newScriptedServer(t, func(call gatewayCall) string {
+		return `{"terminals":[{"iccid":"","msisdn":"13900139000"}]}`
+	})
+	resolver := NewTerminalResolver(srv.client())
+
+	_, err := resolver.ResolveICCID(context.Background(), "13900139000")
+	if !errors.Is(err, ErrTerminalNotFound) {
+		t.Errorf("未找到终端时 error = %v, want ErrTerminalNotFound", err)
+	}
+
+	_, err = resolver.ResolveICCID(context.Background(), "abc")
+	if !hasViolation(err, "Identifier") {
+		t.Errorf("无法识别的标识 error = %v, want Identifier 违规", err)
+	}
+	if n := len(srv.Calls()); n != 1 {
+		t.Errorf("网关收到 %d 次请求, want 1", n)
+	}
+}
@@ -0,0 +1,69 @@
+package request
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 终端用量接口
+	API_TERMINAL_USAGE     = "wsGetTerminalUsage/V1/1Main"
+	API_VER_TERMINAL_USAGE = "V1.1"
+)
+
+// TerminalUsageRequest 查询终端本计费周期截至当前的流量、短信与语音用量（wsGetTerminalUsage）
+type TerminalUsageRequest struct {
+	CommonJsonRequest
+	MessageId string
+	Version   string
+	Iccid     string
+	// CycleStartDate 计费周期开始日期，零值表示当前周期，按东八区日期发送
+	CycleStartDate time.Time
+}
+
+// NewTerminalUsageRequest 创建一个新的查询终端用量请求
+func NewTerminalUsageRequest(iccid string) *TerminalUsageRequest {
+	r := &TerminalUsageRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_TERMINAL_USAGE,
+		Iccid:             iccid,
+	}
+	r.SetApiName(API_TERMINAL_USAGE)
+	r.SetApiVer(API_VER_TERMINAL_USAGE)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *TerminalUsageRequest) GetParams() map[string]interface{} {
+	return r.buildParams(map[string]interface{}{
+		"messageId":      r.MessageId,
+		"version":        r.Version,
+		"iccid":          r.Iccid,
+		"cycleStartDate": formatOptionalDate(r.CycleStartDate),
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *TerminalUsageRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.TerminalUsageResponse{}
+}
+
+// Check 客户端参数检查，以系统当前时间校验计费周期开始日期
+func (r *TerminalUsageRequest) Check() error {
+	return r.CheckAt(time.Now())
+}
+
+// CheckAt 以 now 作为当前时间进行客户端参数检查，计费周期开始日期不能晚于今天（东八区）
+func (r *TerminalUsageRequest) CheckAt(now time.Time) error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+
+	if !IsICCID(r.Iccid) {
+		ex.AddViolation("Iccid", "ICCID格式不正确: "+r.Iccid)
+	}
+	checkCycleStartDate(ex, r.CycleStartDate, now)
+
+	return ex.ErrOrNil()
+}
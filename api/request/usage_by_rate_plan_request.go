@@ -0,0 +1,69 @@
+package request
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+	"github.com/zhoudm1743/unicom-gw/api/response"
+)
+
+const (
+	// 按资费计划查询终端用量接口
+	API_USAGE_BY_RATE_PLAN     = "wsGetTerminalUsageByRatePlan/V1/1Main"
+	API_VER_USAGE_BY_RATE_PLAN = "V1.1"
+)
+
+// UsageByRatePlanRequest 按资费计划查询终端用量请求（wsGetTerminalUsageByRatePlan）
+type UsageByRatePlanRequest struct {
+	CommonJsonRequest
+	MessageId string
+	Version   string
+	Iccid     string
+	// CycleStartDate 计费周期开始日期，零值表示当前周期，按东八区日期发送
+	CycleStartDate time.Time
+}
+
+// NewUsageByRatePlanRequest 创建一个新的按资费计划查询终端用量请求
+func NewUsageByRatePlanRequest(iccid string) *UsageByRatePlanRequest {
+	r := &UsageByRatePlanRequest{
+		CommonJsonRequest: *newQueryRequest(),
+		Version:           API_VER_USAGE_BY_RATE_PLAN,
+		Iccid:             iccid,
+	}
+	r.SetApiName(API_USAGE_BY_RATE_PLAN)
+	r.SetApiVer(API_VER_USAGE_BY_RATE_PLAN)
+	return r
+}
+
+// GetParams 获取请求参数
+func (r *UsageByRatePlanRequest) GetParams() map[string]interface{} {
+	return r.buildParams(map[string]interface{}{
+		"messageId":      r.MessageId,
+		"version":        r.Version,
+		"iccid":          r.Iccid,
+		"cycleStartDate": formatOptionalDate(r.CycleStartDate),
+	})
+}
+
+// GetResponseClass 获取响应类型
+func (r *UsageByRatePlanRequest) GetResponseClass() api.IoTGatewayResponse {
+	return &response.UsageByRatePlanResponse{}
+}
+
+// Check 客户端参数检查，以系统当前时间校验计费周期开始日期
+func (r *UsageByRatePlanRequest) Check() error {
+	return r.CheckAt(time.Now())
+}
+
+// CheckAt 以 now 作为当前时间进行客户端参数检查，计费周期开始日期不能晚于今天（东八区）
+func (r *UsageByRatePlanRequest) CheckAt(now time.Time) error {
+	ex := newRuleException()
+	r.CheckBase(ex, r.GetParams())
+
+	if !IsICCID(r.Iccid) {
+		ex.AddViolation("Iccid", "ICCID格式不正确: "+r.Iccid)
+	}
+	checkCycleStartDate(ex, r.CycleStartDate, now)
+
+	return ex.ErrOrNil()
+}
@@ -1,6 +1,8 @@
 package response
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 
 	"github.com/zhoudm1743/unicom-gw/api"
@@ -27,6 +29,23 @@ func (r *CommonJsonResponse) SetData(data map[string]interface{}) {
 	r.Data = data
 }
 
+// UnmarshalJSON 只在 data 为JSON对象时填充 Data，数组或标量可通过 GetRawData 获取
+func (r *CommonJsonResponse) UnmarshalJSON(b []byte) error {
+	var aux struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	r.Data = nil
+	data := bytes.TrimSpace(aux.Data)
+	if len(data) > 0 && data[0] == '{' {
+		return json.Unmarshal(data, &r.Data)
+	}
+	return nil
+}
+
 // IsSuccess 请求是否成功
 func (r *CommonJsonResponse) IsSuccess() bool {
 	// 如果已设置成功状态，直接返回
@@ -34,6 +53,11 @@ func (r *CommonJsonResponse) IsSuccess() bool {
 		return r.BaseIoTGatewayResponse.IsSuccess()
 	}
 
+	// 网关返回了状态码时以状态码为准
+	if r.GetStatus() != "" {
+		return false
+	}
+
 	// 如果数据为空，则请求失败
 	if r.Data == nil {
 		return false
@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// EditTerminalData 编辑终端业务数据
+type EditTerminalData struct {
+	Iccid         string `json:"iccid"`
+	EffectiveDate string `json:"effectiveDate"`
+	RequestId     string `json:"requestId"`
+}
+
+// EditTerminalResponse 编辑终端响应（wsEditTerminal）
+type EditTerminalResponse struct {
+	api.BaseIoTGatewayResponse
+	Data EditTerminalData `json:"data"`
+}
+
+// GetIccid 获取被编辑终端的ICCID
+func (r *EditTerminalResponse) GetIccid() string {
+	return r.Data.Iccid
+}
+
+// GetRequestId 获取网关返回的变更请求ID
+func (r *EditTerminalResponse) GetRequestId() string {
+	return r.Data.RequestId
+}
+
+// GetEffectiveDate 获取变更生效日期，网关未返回时为零值
+func (r *EditTerminalResponse) GetEffectiveDate() (time.Time, error) {
+	return api.ParseGatewayTime(r.Data.EffectiveDate)
+}
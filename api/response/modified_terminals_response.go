@@ -0,0 +1,49 @@
+package response
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// ModifiedTerminal 有变更的终端
+type ModifiedTerminal struct {
+	Iccid        string `json:"iccid"`
+	DateModified string `json:"dateModified"`
+}
+
+// GetDateModified 获取最近变更时间（东八区），网关未返回时为零值
+func (t ModifiedTerminal) GetDateModified() (time.Time, error) {
+	return api.ParseGatewayTime(t.DateModified)
+}
+
+// ModifiedTerminalsData 变更终端业务数据
+type ModifiedTerminalsData struct {
+	Terminals []ModifiedTerminal `json:"terminals"`
+	LastPage  bool               `json:"lastPage"`
+}
+
+// ModifiedTerminalsResponse 查询变更终端响应（wsGetModifiedTerminals）
+type ModifiedTerminalsResponse struct {
+	api.BaseIoTGatewayResponse
+	Data ModifiedTerminalsData `json:"data"`
+}
+
+// GetTerminals 获取本页有变更的终端
+func (r *ModifiedTerminalsResponse) GetTerminals() []ModifiedTerminal {
+	return r.Data.Terminals
+}
+
+// GetIccids 获取本页有变更的终端ICCID
+func (r *ModifiedTerminalsResponse) GetIccids() []string {
+	iccids := make([]string, 0, len(r.Data.Terminals))
+	for _, t := range r.Data.Terminals {
+		iccids = append(iccids, t.Iccid)
+	}
+	return iccids
+}
+
+// IsLastPage 是否为最后一页
+func (r *ModifiedTerminalsResponse) IsLastPage() bool {
+	return r.Data.LastPage
+}
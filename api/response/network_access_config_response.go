@@ -0,0 +1,77 @@
+package response
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// Apn 网络接入配置中的APN
+type Apn struct {
+	ApnName string `json:"apnName"`
+	// IpAllocation IP地址分配方式，DYNAMIC 或 STATIC
+	IpAllocation string `json:"ipAllocation"`
+	// IpPool 静态地址池名称
+	IpPool    string `json:"ipPool"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// NetworkAccessConfig 网络接入配置
+type NetworkAccessConfig struct {
+	NacId   string `json:"nacId"`
+	NacName string `json:"nacName"`
+	Apns    []Apn  `json:"apns"`
+	// RoamingRestriction 漫游限制，NONE、HOME_ONLY、ALLOW_LIST 或 DENY_LIST
+	RoamingRestriction string `json:"roamingRestriction"`
+	// RoamingOperators 漫游限制对应的运营商PLMN列表
+	RoamingOperators []string `json:"roamingOperators"`
+	DateModified     string   `json:"dateModified"`
+}
+
+// GetDefaultApn 获取默认APN
+func (c NetworkAccessConfig) GetDefaultApn() (Apn, bool) {
+	for _, a := range c.Apns {
+		if a.IsDefault {
+			return a, true
+		}
+	}
+	return Apn{}, false
+}
+
+// GetDateModified 获取最近修改时间（东八区），网关未返回时为零值
+func (c NetworkAccessConfig) GetDateModified() (time.Time, error) {
+	return api.ParseGatewayTime(c.DateModified)
+}
+
+// NetworkAccessConfigResponse 查询网络接入配置响应（wsGetNetworkAccessConfig）
+type NetworkAccessConfigResponse struct {
+	api.BaseIoTGatewayResponse
+	Data NetworkAccessConfig `json:"data"`
+}
+
+// GetConfig 获取网络接入配置
+func (r *NetworkAccessConfigResponse) GetConfig() NetworkAccessConfig {
+	return r.Data
+}
+
+// EditNetworkAccessConfigData 编辑网络接入配置业务数据
+type EditNetworkAccessConfigData struct {
+	NacId     string `json:"nacId"`
+	RequestId string `json:"requestId"`
+}
+
+// EditNetworkAccessConfigResponse 编辑网络接入配置响应（wsEditNetworkAccessConfig）
+type EditNetworkAccessConfigResponse struct {
+	api.BaseIoTGatewayResponse
+	Data EditNetworkAccessConfigData `json:"data"`
+}
+
+// GetNacId 获取被编辑的配置ID
+func (r *EditNetworkAccessConfigResponse) GetNacId() string {
+	return r.Data.NacId
+}
+
+// GetRequestId 获取网关返回的变更请求ID
+func (r *EditNetworkAccessConfigResponse) GetRequestId() string {
+	return r.Data.RequestId
+}
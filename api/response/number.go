@@ -0,0 +1,77 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Number 网关返回的数字，保留原始的十进制文本以免损失精度
+//
+// 与 json.Number 不同，Number 同时接受JSON数字与数字字符串，"" 与 null 视为缺失，解码为空值而不是报错。
+type Number string
+
+// String 返回数字的十进制文本，缺失时为空串
+func (n Number) String() string {
+	return string(n)
+}
+
+// IsEmpty 网关是否未返回该数字
+func (n Number) IsEmpty() bool {
+	return n == ""
+}
+
+// Int64 解析为 int64，缺失时返回0
+func (n Number) Int64() (int64, error) {
+	if n.IsEmpty() {
+		return 0, nil
+	}
+	return json.Number(n).Int64()
+}
+
+// Float64 解析为 float64，缺失时返回0
+func (n Number) Float64() (float64, error) {
+	if n.IsEmpty() {
+		return 0, nil
+	}
+	return json.Number(n).Float64()
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，接受数字、数字字符串、空串与 null
+func (n *Number) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		*n = ""
+		return nil
+	}
+
+	literal := data
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			*n = ""
+			return nil
+		}
+		literal = []byte(s)
+	}
+
+	var num json.Number
+	if err := json.Unmarshal(literal, &num); err != nil || num == "" {
+		return fmt.Errorf("无法解析数字: %s", data)
+	}
+	*n = Number(num)
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler，缺失时输出 null
+func (n Number) MarshalJSON() ([]byte, error) {
+	if n.IsEmpty() {
+		return []byte("null"), nil
+	}
+	return []byte(n), nil
+}
@@ -0,0 +1,98 @@
+package response
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNumberUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    Number
+		wantErr bool
+	}{
+		{"整数", `123`, "123", false},
+		{"大整数保留精度", `12345678901234567890`, "12345678901234567890", false},
+		{"小数", `1.5`, "1.5", false},
+		{"数字字符串", `"2048"`, "2048", false},
+		{"带空白的数字字符串", `" 42 "`, "42", false},
+		{"空串视为缺失", `""`, "", false},
+		{"null视为缺失", `null`, "", false},
+		{"非数字字符串", `"abc"`, "", true},
+		{"布尔值", `true`, "", true},
+		{"对象", `{}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Number
+			err := json.Unmarshal([]byte(tt.json), &n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.json, err, tt.wantErr)
+			}
+			if !tt.wantErr && n != tt.want {
+				t.Errorf("Unmarshal(%s) = %q, want %q", tt.json, n, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedResponsesAcceptEmptyNumbers(t *testing.T) {
+	body := `{"status":"0000","data":{"terminals":[{"iccid":"89860625680009634556","monthToDateUsage":"","overageLimit":null}]}}`
+	var details TerminalDetailsResponse
+	if err := json.Unmarshal([]byte(body), &details); err != nil {
+		t.Fatalf("终端详情解码失败: %v", err)
+	}
+	if terminal := details.GetTerminals()[0]; !terminal.MonthToDateUsage.IsEmpty() || !terminal.OverageLimit.IsEmpty() {
+		t.Errorf("空数字应解码为缺失: %+v", terminal)
+	}
+
+	body = `{"status":"0000","data":{"iccid":"89860625680009634556","dataUsage":"","dataUnit":"MB","smsMOUsage":"3","smsMTUsage":null}}`
+	var usage TerminalUsageResponse
+	if err := json.Unmarshal([]byte(body), &usage); err != nil {
+		t.Fatalf("终端用量解码失败: %v", err)
+	}
+	if n, err := usage.GetUsage().GetDataBytes(); err != nil || n != 0 {
+		t.Errorf("GetDataBytes() = %d, %v, want 0", n, err)
+	}
+	if n, err := usage.GetUsage().GetSmsCount(); err != nil || n != 3 {
+		t.Errorf("GetSmsCount() = %d, %v, want 3", n, err)
+	}
+
+	body = `{"status":"0000","data":{"ratePlans":[{"ratePlanId":"","ratePlanName":"基础套餐"}],"lastPage":true}}`
+	var plans RatePlansResponse
+	if err := json.Unmarshal([]byte(body), &plans); err != nil {
+		t.Fatalf("资费计划解码失败: %v", err)
+	}
+}
+
+func TestDataBytes(t *testing.T) {
+	tests := []struct {
+		value   Number
+		unit    string
+		want    int64
+		wantErr bool
+	}{
+		{"", "MB", 0, false},
+		{"1024", "", 1024, false},
+		{"1", "KB", 1024, false},
+		{"1.5", "MB", 1572864, false},
+		{"0.0001", "KB", 0, false},
+		{"0.0005", "MB", 524, false},
+		{"2", "gb", 2 << 30, false},
+		{"9223372036854775807", "KB", 0, true},
+		{"1", "TB", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := DataBytes(tt.value, tt.unit)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("DataBytes(%q, %q) error = %v, wantErr %v", tt.value, tt.unit, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("DataBytes(%q, %q) = %d, want %d", tt.value, tt.unit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package response
+
+import (
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// RatePlan 资费计划
+type RatePlan struct {
+	RatePlanId   Number `json:"ratePlanId"`
+	RatePlanName string `json:"ratePlanName"`
+	Description  string `json:"description"`
+	Status       string `json:"status"`
+}
+
+// RatePlansData 资费计划列表业务数据
+type RatePlansData struct {
+	RatePlans []RatePlan `json:"ratePlans"`
+	LastPage  bool       `json:"lastPage"`
+}
+
+// RatePlansResponse 查询资费计划列表响应（wsGetRatePlans）
+type RatePlansResponse struct {
+	api.BaseIoTGatewayResponse
+	Data RatePlansData `json:"data"`
+}
+
+// GetRatePlans 获取本页资费计划列表
+func (r *RatePlansResponse) GetRatePlans() []RatePlan {
+	return r.Data.RatePlans
+}
+
+// IsLastPage 是否为最后一页
+func (r *RatePlansResponse) IsLastPage() bool {
+	return r.Data.LastPage
+}
+
+// CommunicationPlan 通信计划
+type CommunicationPlan struct {
+	CommunicationPlanId   Number `json:"communicationPlanId"`
+	CommunicationPlanName string `json:"communicationPlanName"`
+	Description           string `json:"description"`
+	Status                string `json:"status"`
+}
+
+// CommunicationPlansData 通信计划列表业务数据
+type CommunicationPlansData struct {
+	CommunicationPlans []CommunicationPlan `json:"communicationPlans"`
+	LastPage           bool                `json:"lastPage"`
+}
+
+// CommunicationPlansResponse 查询通信计划列表响应（wsGetCommunicationPlans）
+type CommunicationPlansResponse struct {
+	api.BaseIoTGatewayResponse
+	Data CommunicationPlansData `json:"data"`
+}
+
+// GetCommunicationPlans 获取本页通信计划列表
+func (r *CommunicationPlansResponse) GetCommunicationPlans() []CommunicationPlan {
+	return r.Data.CommunicationPlans
+}
+
+// IsLastPage 是否为最后一页
+func (r *CommunicationPlansResponse) IsLastPage() bool {
+	return r.Data.LastPage
+}
@@ -0,0 +1,21 @@
+package response
+
+import (
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// SendSmsData 发送短信业务数据
+type SendSmsData struct {
+	SmsMsgId string `json:"smsMsgId"`
+}
+
+// SendSmsResponse 发送短信响应（wsSendSMS）
+type SendSmsResponse struct {
+	api.BaseIoTGatewayResponse
+	Data SendSmsData `json:"data"`
+}
+
+// GetSmsMsgId 获取网关分配的短信ID，可用于查询短信详情
+func (r *SendSmsResponse) GetSmsMsgId() string {
+	return r.Data.SmsMsgId
+}
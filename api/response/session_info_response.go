@@ -0,0 +1,66 @@
+package response
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// SessionInfo 终端数据会话信息，网关未返回的字段为空
+type SessionInfo struct {
+	Iccid              string `json:"iccid"`
+	IpAddress          string `json:"ipAddress"`
+	Ipv6Address        string `json:"ipv6Address"`
+	DateSessionStarted string `json:"dateSessionStarted"`
+	DateSessionEnded   string `json:"dateSessionEnded"`
+	Apn                string `json:"apn"`
+	// RatType 无线接入技术，如 2G、3G、4G、5G、NB-IoT
+	RatType string `json:"ratType"`
+}
+
+// IsOnline 是否处于会话中：会话已开始且未结束
+func (s SessionInfo) IsOnline() bool {
+	return s.DateSessionStarted != "" && s.DateSessionEnded == ""
+}
+
+// GetSessionStartTime 获取会话开始时间（东八区），网关未返回时为零值
+func (s SessionInfo) GetSessionStartTime() (time.Time, error) {
+	return api.ParseGatewayTime(s.DateSessionStarted)
+}
+
+// GetSessionEndTime 获取会话结束时间（东八区），会话未结束时为零值
+func (s SessionInfo) GetSessionEndTime() (time.Time, error) {
+	return api.ParseGatewayTime(s.DateSessionEnded)
+}
+
+// SessionInfoData 会话信息业务数据
+type SessionInfoData struct {
+	SessionInfo []SessionInfo `json:"sessionInfo"`
+}
+
+// SessionInfoResponse 查询会话信息响应（wsGetSessionInfo）
+type SessionInfoResponse struct {
+	api.BaseIoTGatewayResponse
+	Data SessionInfoData `json:"data"`
+}
+
+// GetSessions 获取会话信息列表
+func (r *SessionInfoResponse) GetSessions() []SessionInfo {
+	return r.Data.SessionInfo
+}
+
+// GetSession 按ICCID获取会话信息
+func (r *SessionInfoResponse) GetSession(iccid string) (SessionInfo, bool) {
+	for _, s := range r.Data.SessionInfo {
+		if s.Iccid == iccid {
+			return s, true
+		}
+	}
+	return SessionInfo{}, false
+}
+
+// IsOnline 指定ICCID的终端是否在线，响应中没有该终端时返回false
+func (r *SessionInfoResponse) IsOnline(iccid string) bool {
+	s, ok := r.GetSession(iccid)
+	return ok && s.IsOnline()
+}
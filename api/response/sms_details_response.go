@@ -0,0 +1,75 @@
+package response
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// 短信方向
+const (
+	SmsTypeMT = "MT" // 下行，发往终端
+	SmsTypeMO = "MO" // 上行，终端发出
+)
+
+// SmsDetail 短信详情
+type SmsDetail struct {
+	SmsMsgId        string `json:"smsMsgId"`
+	Status          string `json:"status"`
+	MessageText     string `json:"messageText"`
+	SenderLogin     string `json:"senderLogin"`
+	SentToIccid     string `json:"sentToIccid"`
+	SentToMsisdn    string `json:"sentToMsisdn"`
+	SentFrom        string `json:"sentFrom"`
+	MsgType         string `json:"msgType"`
+	DateSent        string `json:"dateSent"`
+	DateReceived    string `json:"dateReceived"`
+	DateModified    string `json:"dateModified"`
+	MessageEncoding string `json:"messageEncoding"`
+}
+
+// IsMT 是否为下行短信
+func (d SmsDetail) IsMT() bool {
+	return d.MsgType == SmsTypeMT
+}
+
+// IsMO 是否为上行短信
+func (d SmsDetail) IsMO() bool {
+	return d.MsgType == SmsTypeMO
+}
+
+// GetDateSent 获取发送时间（东八区），网关未返回时为零值
+func (d SmsDetail) GetDateSent() (time.Time, error) {
+	return api.ParseGatewayTime(d.DateSent)
+}
+
+// GetDateReceived 获取接收时间（东八区），网关未返回时为零值
+func (d SmsDetail) GetDateReceived() (time.Time, error) {
+	return api.ParseGatewayTime(d.DateReceived)
+}
+
+// SmsDetailsData 短信详情业务数据
+type SmsDetailsData struct {
+	SmsMessages []SmsDetail `json:"smsMessages"`
+}
+
+// SmsDetailsResponse 查询短信详情响应（wsGetSMSDetails）
+type SmsDetailsResponse struct {
+	api.BaseIoTGatewayResponse
+	Data SmsDetailsData `json:"data"`
+}
+
+// GetSmsMessages 获取短信详情列表
+func (r *SmsDetailsResponse) GetSmsMessages() []SmsDetail {
+	return r.Data.SmsMessages
+}
+
+// GetSmsMessage 按短信ID获取短信详情
+func (r *SmsDetailsResponse) GetSmsMessage(smsMsgId string) (SmsDetail, bool) {
+	for _, m := range r.Data.SmsMessages {
+		if m.SmsMsgId == smsMsgId {
+			return m, true
+		}
+	}
+	return SmsDetail{}, false
+}
@@ -0,0 +1,63 @@
+package response
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// AuditTrailEntry 终端的一条变更记录
+type AuditTrailEntry struct {
+	Field      string `json:"field"`
+	PriorValue string `json:"priorValue"`
+	Value      string `json:"value"`
+	// UserName 操作人，系统自动变更时为空
+	UserName string `json:"userName"`
+	// Source 变更来源，如 API、WEB、SYSTEM
+	Source        string `json:"source"`
+	IpAddress     string `json:"ipAddress"`
+	Status        string `json:"status"`
+	DateChanged   string `json:"dateChanged"`
+	EffectiveDate string `json:"effectiveDate"`
+}
+
+// GetDateChanged 获取变更时间（东八区），网关未返回时为零值
+func (e AuditTrailEntry) GetDateChanged() (time.Time, error) {
+	return api.ParseGatewayTime(e.DateChanged)
+}
+
+// GetEffectiveDate 获取生效时间（东八区），网关未返回时为零值
+func (e AuditTrailEntry) GetEffectiveDate() (time.Time, error) {
+	return api.ParseGatewayTime(e.EffectiveDate)
+}
+
+// GetOperator 获取操作人，没有操作人时返回变更来源
+func (e AuditTrailEntry) GetOperator() string {
+	if e.UserName != "" {
+		return e.UserName
+	}
+	return e.Source
+}
+
+// TerminalAuditTrailData 终端变更历史业务数据
+type TerminalAuditTrailData struct {
+	Iccid              string            `json:"iccid"`
+	TerminalAuditTrail []AuditTrailEntry `json:"terminalAuditTrail"`
+	LastPage           bool              `json:"lastPage"`
+}
+
+// TerminalAuditTrailResponse 查询终端变更历史响应（wsGetTerminalAuditTrail）
+type TerminalAuditTrailResponse struct {
+	api.BaseIoTGatewayResponse
+	Data TerminalAuditTrailData `json:"data"`
+}
+
+// GetEntries 获取本页变更记录
+func (r *TerminalAuditTrailResponse) GetEntries() []AuditTrailEntry {
+	return r.Data.TerminalAuditTrail
+}
+
+// IsLastPage 是否为最后一页
+func (r *TerminalAuditTrailResponse) IsLastPage() bool {
+	return r.Data.LastPage
+}
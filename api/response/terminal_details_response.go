@@ -0,0 +1,48 @@
+package response
+
+import (
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// TerminalDetail 终端详情
+type TerminalDetail struct {
+	Iccid             string `json:"iccid"`
+	Imsi              string `json:"imsi"`
+	Msisdn            string `json:"msisdn"`
+	Imei              string `json:"imei"`
+	Status            string `json:"status"`
+	RatePlan          string `json:"ratePlan"`
+	CommunicationPlan string `json:"communicationPlan"`
+	AccountId         string `json:"accountId"`
+	DateActivated     string `json:"dateActivated"`
+	DateAdded         string `json:"dateAdded"`
+	DateModified      string `json:"dateModified"`
+	MonthToDateUsage  Number `json:"monthToDateUsage"`
+	OverageLimit      Number `json:"overageLimit"`
+}
+
+// TerminalDetailsData 终端详情业务数据
+type TerminalDetailsData struct {
+	Terminals []TerminalDetail `json:"terminals"`
+}
+
+// TerminalDetailsResponse 查询终端详情响应（wsGetTerminalDetails）
+type TerminalDetailsResponse struct {
+	api.BaseIoTGatewayResponse
+	Data TerminalDetailsData `json:"data"`
+}
+
+// GetTerminals 获取终端详情列表
+func (r *TerminalDetailsResponse) GetTerminals() []TerminalDetail {
+	return r.Data.Terminals
+}
+
+// GetTerminal 按ICCID获取终端详情
+func (r *TerminalDetailsResponse) GetTerminal(iccid string) (TerminalDetail, bool) {
+	for _, t := range r.Data.Terminals {
+		if t.Iccid == iccid {
+			return t, true
+		}
+	}
+	return TerminalDetail{}, false
+}
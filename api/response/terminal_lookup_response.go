@@ -0,0 +1,59 @@
+package response
+
+import (
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// TerminalIdentity 终端标识
+type TerminalIdentity struct {
+	Iccid  string `json:"iccid"`
+	Imsi   string `json:"imsi"`
+	Msisdn string `json:"msisdn"`
+}
+
+// TerminalLookupData 按MSISDN或IMSI查询终端的业务数据
+type TerminalLookupData struct {
+	Terminals []TerminalIdentity `json:"terminals"`
+}
+
+// TerminalLookupResponse 按MSISDN或IMSI查询终端响应（wsGetTerminalsByMsisdn / wsGetTerminalsByImsi）
+type TerminalLookupResponse struct {
+	api.BaseIoTGatewayResponse
+	Data TerminalLookupData `json:"data"`
+}
+
+// GetTerminals 获取查询到的终端标识
+func (r *TerminalLookupResponse) GetTerminals() []TerminalIdentity {
+	return r.Data.Terminals
+}
+
+// GetIccids 获取查询到的ICCID，忽略空值
+func (r *TerminalLookupResponse) GetIccids() []string {
+	iccids := make([]string, 0, len(r.Data.Terminals))
+	for _, t := range r.Data.Terminals {
+		if t.Iccid != "" {
+			iccids = append(iccids, t.Iccid)
+		}
+	}
+	return iccids
+}
+
+// GetIccidByMsisdn 按MSISDN获取ICCID
+func (r *TerminalLookupResponse) GetIccidByMsisdn(msisdn string) (string, bool) {
+	for _, t := range r.Data.Terminals {
+		if t.Msisdn == msisdn && t.Iccid != "" {
+			return t.Iccid, true
+		}
+	}
+	return "", false
+}
+
+// GetIccidByImsi 按IMSI获取ICCID
+func (r *TerminalLookupResponse) GetIccidByImsi(imsi string) (string, bool) {
+	for _, t := range r.Data.Terminals {
+		if t.Imsi == imsi && t.Iccid != "" {
+			return t.Iccid, true
+		}
+	}
+	return "", false
+}
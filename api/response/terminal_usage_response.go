@@ -0,0 +1,75 @@
+package response
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// TerminalUsageData 终端本计费周期截至当前的用量
+type TerminalUsageData struct {
+	Iccid          string `json:"iccid"`
+	CycleStartDate string `json:"cycleStartDate"`
+	CycleEndDate   string `json:"cycleEndDate"`
+	// DataUsage 流量用量，单位见 DataUnit，为空时按字节处理
+	DataUsage Number `json:"dataUsage"`
+	DataUnit  string `json:"dataUnit"`
+	// SmsMOUsage、SmsMTUsage 上行、下行短信条数
+	SmsMOUsage Number `json:"smsMOUsage"`
+	SmsMTUsage Number `json:"smsMTUsage"`
+	// VoiceMOUsage、VoiceMTUsage 主叫、被叫通话时长（秒）
+	VoiceMOUsage Number `json:"voiceMOUsage"`
+	VoiceMTUsage Number `json:"voiceMTUsage"`
+}
+
+// GetDataBytes 获取流量用量（字节）
+func (d TerminalUsageData) GetDataBytes() (int64, error) {
+	return DataBytes(d.DataUsage, d.DataUnit)
+}
+
+// GetSmsCount 获取上行与下行短信总条数
+func (d TerminalUsageData) GetSmsCount() (int64, error) {
+	mo, err := usageCount(d.SmsMOUsage)
+	if err != nil {
+		return 0, err
+	}
+	mt, err := usageCount(d.SmsMTUsage)
+	if err != nil {
+		return 0, err
+	}
+	return mo + mt, nil
+}
+
+// GetVoiceSeconds 获取主叫与被叫通话总时长（秒）
+func (d TerminalUsageData) GetVoiceSeconds() (int64, error) {
+	mo, err := usageCount(d.VoiceMOUsage)
+	if err != nil {
+		return 0, err
+	}
+	mt, err := usageCount(d.VoiceMTUsage)
+	if err != nil {
+		return 0, err
+	}
+	return mo + mt, nil
+}
+
+// GetCycleStartDate 获取计费周期开始日期（东八区），网关未返回时为零值
+func (d TerminalUsageData) GetCycleStartDate() (time.Time, error) {
+	return api.ParseGatewayTime(d.CycleStartDate)
+}
+
+// GetCycleEndDate 获取计费周期结束日期（东八区），网关未返回时为零值
+func (d TerminalUsageData) GetCycleEndDate() (time.Time, error) {
+	return api.ParseGatewayTime(d.CycleEndDate)
+}
+
+// TerminalUsageResponse 查询终端用量响应（wsGetTerminalUsage）
+type TerminalUsageResponse struct {
+	api.BaseIoTGatewayResponse
+	Data TerminalUsageData `json:"data"`
+}
+
+// GetUsage 获取终端用量
+func (r *TerminalUsageResponse) GetUsage() TerminalUsageData {
+	return r.Data
+}
@@ -0,0 +1,81 @@
+package response
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api"
+)
+
+// RatePlanUsage 终端在单个资费计划下的用量
+type RatePlanUsage struct {
+	RatePlan string `json:"ratePlan"`
+	ZoneName string `json:"zoneName"`
+	// DataUsage 流量用量，单位见 DataUnit，为空时按字节处理
+	DataUsage Number `json:"dataUsage"`
+	DataUnit  string `json:"dataUnit"`
+	// SmsUsage 短信条数
+	SmsUsage Number `json:"smsUsage"`
+	// VoiceUsage 通话时长（秒）
+	VoiceUsage Number `json:"voiceUsage"`
+}
+
+// GetDataBytes 获取流量用量（字节）
+func (u RatePlanUsage) GetDataBytes() (int64, error) {
+	return DataBytes(u.DataUsage, u.DataUnit)
+}
+
+// GetSmsCount 获取短信条数
+func (u RatePlanUsage) GetSmsCount() (int64, error) {
+	return usageCount(u.SmsUsage)
+}
+
+// GetVoiceSeconds 获取通话时长（秒）
+func (u RatePlanUsage) GetVoiceSeconds() (int64, error) {
+	return usageCount(u.VoiceUsage)
+}
+
+// UsageByRatePlanData 按资费计划统计的用量业务数据
+type UsageByRatePlanData struct {
+	Iccid          string          `json:"iccid"`
+	CycleStartDate string          `json:"cycleStartDate"`
+	RatePlanUsages []RatePlanUsage `json:"ratePlanUsages"`
+}
+
+// UsageByRatePlanResponse 按资费计划查询终端用量响应（wsGetTerminalUsageByRatePlan）
+type UsageByRatePlanResponse struct {
+	api.BaseIoTGatewayResponse
+	Data UsageByRatePlanData `json:"data"`
+}
+
+// GetRatePlanUsages 获取各资费计划下的用量
+func (r *UsageByRatePlanResponse) GetRatePlanUsages() []RatePlanUsage {
+	return r.Data.RatePlanUsages
+}
+
+// GetRatePlanUsage 按资费计划名称获取用量
+func (r *UsageByRatePlanResponse) GetRatePlanUsage(ratePlan string) (RatePlanUsage, bool) {
+	for _, u := range r.Data.RatePlanUsages {
+		if u.RatePlan == ratePlan {
+			return u, true
+		}
+	}
+	return RatePlanUsage{}, false
+}
+
+// GetCycleStartDate 获取计费周期开始日期（东八区），网关未返回时为零值
+func (r *UsageByRatePlanResponse) GetCycleStartDate() (time.Time, error) {
+	return api.ParseGatewayTime(r.Data.CycleStartDate)
+}
+
+// GetTotalDataBytes 获取全部资费计划的流量用量合计（字节）
+func (r *UsageByRatePlanResponse) GetTotalDataBytes() (int64, error) {
+	var total int64
+	for _, u := range r.Data.RatePlanUsages {
+		n, err := u.GetDataBytes()
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
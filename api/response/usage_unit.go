@@ -0,0 +1,75 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// 流量单位，按1024进制换算
+const (
+	DataUnitByte = "B"
+	DataUnitKB   = "KB"
+	DataUnitMB   = "MB"
+	DataUnitGB   = "GB"
+)
+
+// dataUnitBytes 各流量单位对应的字节数
+var dataUnitBytes = map[string]int64{
+	"":           1,
+	DataUnitByte: 1,
+	"BYTE":       1,
+	"BYTES":      1,
+	DataUnitKB:   1 << 10,
+	DataUnitMB:   1 << 20,
+	DataUnitGB:   1 << 30,
+}
+
+// DataBytes 将网关返回的流量值按单位换算为字节数，单位为空时按字节处理
+//
+// 换算使用精确的十进制运算，不经过 float64；不足1字节的部分四舍五入。
+func DataBytes(value Number, unit string) (int64, error) {
+	s := strings.TrimSpace(value.String())
+	if s == "" {
+		return 0, nil
+	}
+
+	factor, ok := dataUnitBytes[strings.ToUpper(strings.TrimSpace(unit))]
+	if !ok {
+		return 0, fmt.Errorf("不支持的流量单位: %s", unit)
+	}
+
+	// 整数直接换算，避免大数经过有理数运算
+	if n, err := json.Number(s).Int64(); err == nil {
+		if n > math.MaxInt64/factor || n < math.MinInt64/factor {
+			return 0, fmt.Errorf("流量值超出范围: %s%s", s, unit)
+		}
+		return n * factor, nil
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("无法解析流量值: %s", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(factor))
+
+	// 四舍五入到整字节
+	half := big.NewRat(1, 2)
+	if r.Sign() < 0 {
+		r.Sub(r, half)
+	} else {
+		r.Add(r, half)
+	}
+	bytes := new(big.Int).Quo(r.Num(), r.Denom())
+	if !bytes.IsInt64() {
+		return 0, fmt.Errorf("流量值超出范围: %s%s", s, unit)
+	}
+	return bytes.Int64(), nil
+}
+
+// usageCount 将短信条数、通话秒数等计数解析为 int64，为空时返回0
+func usageCount(value Number) (int64, error) {
+	return value.Int64()
+}
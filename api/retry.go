@@ -0,0 +1,359 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api/internal/utils"
+)
+
+// HTTPError HTTP状态码错误（状态码>=400），Header 与 Body 保留原始响应
+type HTTPError = utils.HTTPError
+
+// RetryAttempt 单次尝试的结果
+type RetryAttempt struct {
+	// Attempt 尝试序号，从1开始
+	Attempt int
+	// StatusCode HTTP状态码，未收到响应时为0
+	StatusCode int
+	// Header 响应头
+	Header http.Header
+	// Body 响应体
+	Body string
+	// Err 本次尝试的错误
+	Err error
+	// Idempotent 请求是否可以安全地重复发送，取自 Call.Idempotent
+	Idempotent bool
+	// Latency 本次尝试耗时
+	Latency time.Duration
+	// WillRetry 是否会继续重试
+	WillRetry bool
+	// Delay 下次重试前的等待时长
+	Delay time.Duration
+}
+
+// RetryPolicy 重试策略，重试次数上限由客户端的 RetryCount 控制
+type RetryPolicy interface {
+	// ShouldRetry 根据本次尝试结果判断是否重试，返回重试前的等待时长
+	ShouldRetry(attempt *RetryAttempt) (time.Duration, bool)
+}
+
+// RetryCallback 每次尝试结束后的回调
+type RetryCallback func(attempt RetryAttempt)
+
+const (
+	// maxBackoffExponent 退避指数上限，避免多次重试后 math.Pow 溢出
+	maxBackoffExponent = 32
+
+	// maxRetryDelay 未设置 MaxDelay 时单次等待时长的上限
+	maxRetryDelay = time.Hour
+)
+
+// ExponentialBackoffRetryPolicy 指数退避加随机抖动的重试策略
+//
+// 非幂等请求（发送短信、修改终端等）只重试请求发出前的失败（NotSentError），避免网关重复执行；
+// 幂等请求还会重试读取超时、连接中断与 HTTP 5xx/429。RetryableCodes 中的网关业务状态码对所有请求生效，
+// 只应配置表示请求未被执行的状态码，如时间戳拒绝。响应带有 Retry-After 时以其为准，但不超过等待时长上限。
+type ExponentialBackoffRetryPolicy struct {
+	// BaseDelay 首次重试的等待时长
+	BaseDelay time.Duration
+	// MaxDelay 等待时长上限，0表示不超过1小时
+	MaxDelay time.Duration
+	// Multiplier 每次重试等待时长的增长倍数
+	Multiplier float64
+	// Jitter 随机抖动比例，取值0~1
+	Jitter float64
+	// RetryableCodes 需要重试的网关业务状态码
+	RetryableCodes []string
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewExponentialBackoffRetryPolicy 创建默认的指数退避重试策略
+func NewExponentialBackoffRetryPolicy() *ExponentialBackoffRetryPolicy {
+	return &ExponentialBackoffRetryPolicy{
+		BaseDelay:  200 * time.Millisecond,
+		MaxDelay:   5 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+}
+
+// ShouldRetry 根据本次尝试结果判断是否重试，返回重试前的等待时长
+func (p *ExponentialBackoffRetryPolicy) ShouldRetry(attempt *RetryAttempt) (time.Duration, bool) {
+	if !p.isRetryable(attempt) {
+		return 0, false
+	}
+
+	// 优先使用服务端给出的 Retry-After，超过上限时按上限等待
+	if delay, ok := parseRetryAfter(attempt.Header); ok {
+		if maxDelay := p.maxDelay(); delay > maxDelay {
+			delay = maxDelay
+		}
+		return delay, true
+	}
+
+	return p.backoff(attempt.Attempt), true
+}
+
+// isRetryable 判断本次尝试结果是否可以重试
+func (p *ExponentialBackoffRetryPolicy) isRetryable(attempt *RetryAttempt) bool {
+	if attempt.Err == nil {
+		if len(p.RetryableCodes) == 0 {
+			return false
+		}
+		env, err := DecodeEnvelope(attempt.Body)
+		if err != nil {
+			return false
+		}
+		status := env.Status
+		for _, code := range p.RetryableCodes {
+			if status != "" && status == code {
+				return true
+			}
+		}
+		return false
+	}
+	if !attempt.Idempotent {
+		return IsNotSentError(attempt.Err)
+	}
+	return IsRetryableError(attempt.Err)
+}
+
+// maxDelay 单次等待时长上限，未设置 MaxDelay 时为 maxRetryDelay
+func (p *ExponentialBackoffRetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay <= 0 {
+		return maxRetryDelay
+	}
+	return p.MaxDelay
+}
+
+// backoff 计算第 n 次尝试失败后的等待时长
+func (p *ExponentialBackoffRetryPolicy) backoff(n int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	exponent := n - 1
+	if exponent < 0 {
+		exponent = 0
+	} else if exponent > maxBackoffExponent {
+		exponent = maxBackoffExponent
+	}
+
+	maxDelay := float64(p.maxDelay())
+
+	delay := float64(p.BaseDelay) * math.Pow(multiplier, float64(exponent))
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	if p.Jitter > 0 {
+		p.mu.Lock()
+		if p.rand == nil {
+			p.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+		}
+		factor := 1 + p.Jitter*(2*p.rand.Float64()-1)
+		p.mu.Unlock()
+		delay *= factor
+	}
+
+	// 抖动后仍不超过上限
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return time.Duration(delay)
+}
+
+// IsRetryableError 判断错误是否属于可重试的连接错误、超时或 HTTP 5xx/429
+//
+// 其中读取超时、连接中断与 5xx 可能发生在网关已执行请求之后，只适用于幂等请求。
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500 || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+
+	// 调用方主动取消或截止时间已到，不再重试
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// IsNotSentError 判断请求是否在发出前失败，此类错误对任何请求重试都不会导致网关重复执行
+func IsNotSentError(err error) bool {
+	var notSent *NotSentError
+	return errors.As(err, &notSent)
+}
+
+// parseRetryAfter 解析 Retry-After 响应头，支持秒数和HTTP日期两种格式
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	if header == nil {
+		return 0, false
+	}
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// RetryInterceptor 按重试策略重试调用，最多重试 maxRetries 次，ctx 的截止时间是全部尝试的总预算
+//
+// 客户端构建的调用在每次重试前重新签名，刷新时间戳与令牌，交易ID与 messageId 保持不变，便于网关识别重复请求。
+func RetryInterceptor(policy RetryPolicy, maxRetries int, callback RetryCallback) Interceptor {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*CallResult, error) {
+			for n := 1; ; n++ {
+				// 上下文已取消或超时，不再发起新的尝试
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
+
+				attemptCall := call.clone()
+				attemptCall.Attempt = n
+				if n > 1 && attemptCall.resign != nil {
+					if err := attemptCall.resign(attemptCall); err != nil {
+						return nil, err
+					}
+				}
+
+				start := time.Now()
+				result, err := next(ctx, attemptCall)
+
+				attempt := RetryAttempt{
+					Attempt:    n,
+					Err:        err,
+					Idempotent: call.Idempotent,
+					Latency:    time.Since(start),
+				}
+				if result != nil {
+					attempt.StatusCode = result.StatusCode
+					attempt.Header = result.Header
+					attempt.Body = result.Body
+				}
+				var httpErr *HTTPError
+				if errors.As(err, &httpErr) {
+					attempt.StatusCode = httpErr.StatusCode
+					attempt.Header = httpErr.Header
+					attempt.Body = httpErr.Body
+				}
+
+				// 请求因上下文结束而失败，返回上下文错误
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					attempt.Err = ctxErr
+					if callback != nil {
+						callback(attempt)
+					}
+					return nil, ctxErr
+				}
+
+				if n <= maxRetries && policy != nil {
+					attempt.Delay, attempt.WillRetry = policy.ShouldRetry(&attempt)
+				}
+
+				// 剩余时间不足以等待下次重试时直接结束
+				if attempt.WillRetry {
+					if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < attempt.Delay {
+						attempt.WillRetry = false
+					}
+				}
+
+				if callback != nil {
+					callback(attempt)
+				}
+
+				if !attempt.WillRetry {
+					return result, err
+				}
+
+				if err := sleepContext(ctx, attempt.Delay); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
+
+// retryLogCallback 在重试前记录日志，再调用调用方的回调
+func retryLogCallback(logger Logger, callback RetryCallback) RetryCallback {
+	if logger == nil {
+		return callback
+	}
+	if _, ok := logger.(nopLogger); ok {
+		return callback
+	}
+
+	return func(attempt RetryAttempt) {
+		if attempt.WillRetry {
+			fields := []LogField{
+				Field(LOG_FIELD_ATTEMPT, attempt.Attempt),
+				Field(LOG_FIELD_STATUS_CODE, attempt.StatusCode),
+				Field(LOG_FIELD_RETRY_DELAY, attempt.Delay),
+			}
+			if attempt.Err != nil {
+				fields = append(fields, Field(LOG_FIELD_ERROR, attempt.Err))
+			}
+			logRedacted(logger, LogLevelInfo, "准备重试网关请求", fields...)
+		}
+		if callback != nil {
+			callback(attempt)
+		}
+	}
+}
+
+// sleepContext 等待指定时长，ctx 结束时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,364 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"HTTP 500", &HTTPError{StatusCode: http.StatusInternalServerError}, true},
+		{"HTTP 503", &HTTPError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"HTTP 429", &HTTPError{StatusCode: http.StatusTooManyRequests}, true},
+		{"HTTP 400", &HTTPError{StatusCode: http.StatusBadRequest}, false},
+		{"HTTP 404", &HTTPError{StatusCode: http.StatusNotFound}, false},
+		{"包装的HTTP 502", fmt.Errorf("wrap: %w", &HTTPError{StatusCode: http.StatusBadGateway}), true},
+		{"阶段超时", &TimeoutError{Phase: TIMEOUT_PHASE_FIRST_BYTE}, true},
+		{"上下文取消", context.Canceled, false},
+		{"上下文截止", context.DeadlineExceeded, false},
+		{"连接错误", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{"连接中断", io.ErrUnexpectedEOF, true},
+		{"EOF", io.EOF, true},
+		{"其他错误", errors.New("boom"), false},
+		{"参数校验失败", NewApiRuleException("请求参数校验失败", ERR_CODE_INVALID, nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableError(tt.err); got != tt.want {
+				t.Errorf("IsRetryableError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoffShouldRetry(t *testing.T) {
+	policy := &ExponentialBackoffRetryPolicy{
+		BaseDelay:      100 * time.Millisecond,
+		MaxDelay:       time.Second,
+		Multiplier:     2,
+		RetryableCodes: []string{"1001"},
+	}
+
+	tests := []struct {
+		name      string
+		attempt   RetryAttempt
+		wantRetry bool
+		wantDelay time.Duration
+	}{
+		{
+			name:    "成功响应不重试",
+			attempt: RetryAttempt{Attempt: 1, Body: `{"status":"0000"}`},
+		},
+		{
+			name:    "不在列表中的业务状态码不重试",
+			attempt: RetryAttempt{Attempt: 1, Body: `{"status":"1002"}`},
+		},
+		{
+			name:    "无法解析的响应不重试",
+			attempt: RetryAttempt{Attempt: 1, Body: `not json`},
+		},
+		{
+			name:      "可重试的业务状态码",
+			attempt:   RetryAttempt{Attempt: 2, Body: `{"status":"1001"}`},
+			wantRetry: true,
+			wantDelay: 200 * time.Millisecond,
+		},
+		{
+			name:      "幂等请求5xx按退避等待",
+			attempt:   RetryAttempt{Attempt: 3, Err: &HTTPError{StatusCode: 500}, Idempotent: true},
+			wantRetry: true,
+			wantDelay: 400 * time.Millisecond,
+		},
+		{
+			name:      "退避不超过MaxDelay",
+			attempt:   RetryAttempt{Attempt: 10, Err: &HTTPError{StatusCode: 500}, Idempotent: true},
+			wantRetry: true,
+			wantDelay: time.Second,
+		},
+		{
+			name: "Retry-After秒数优先",
+			attempt: RetryAttempt{
+				Attempt:    1,
+				Err:        &HTTPError{StatusCode: 429},
+				Header:     http.Header{"Retry-After": []string{"0"}},
+				Idempotent: true,
+			},
+			wantRetry: true,
+			wantDelay: 0,
+		},
+		{
+			name: "Retry-After不超过MaxDelay",
+			attempt: RetryAttempt{
+				Attempt:    1,
+				Err:        &HTTPError{StatusCode: 503},
+				Header:     http.Header{"Retry-After": []string{"86400"}},
+				Idempotent: true,
+			},
+			wantRetry: true,
+			wantDelay: time.Second,
+		},
+		{
+			name:    "4xx不重试",
+			attempt: RetryAttempt{Attempt: 1, Err: &HTTPError{StatusCode: 403}, Idempotent: true},
+		},
+		{
+			name:    "非幂等请求5xx不重试",
+			attempt: RetryAttempt{Attempt: 1, Err: &HTTPError{StatusCode: 503}},
+		},
+		{
+			name:    "非幂等请求读取超时不重试",
+			attempt: RetryAttempt{Attempt: 1, Err: &TimeoutError{Phase: TIMEOUT_PHASE_FIRST_BYTE}},
+		},
+		{
+			name:      "非幂等请求连接超时重试",
+			attempt:   RetryAttempt{Attempt: 1, Err: &NotSentError{Cause: &TimeoutError{Phase: TIMEOUT_PHASE_CONNECT}}},
+			wantRetry: true,
+			wantDelay: 100 * time.Millisecond,
+		},
+		{
+			name:      "非幂等请求的业务状态码重试",
+			attempt:   RetryAttempt{Attempt: 1, Body: `{"status":"1001"}`},
+			wantRetry: true,
+			wantDelay: 100 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt := tt.attempt
+			delay, retry := policy.ShouldRetry(&attempt)
+			if retry != tt.wantRetry {
+				t.Fatalf("ShouldRetry() retry = %v, want %v", retry, tt.wantRetry)
+			}
+			if retry && delay != tt.wantDelay {
+				t.Errorf("ShouldRetry() delay = %s, want %s", delay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoffDoesNotOverflow(t *testing.T) {
+	policy := &ExponentialBackoffRetryPolicy{
+		BaseDelay:  time.Second,
+		Multiplier: 10,
+		Jitter:     0.5,
+	}
+
+	for _, n := range []int{1, 20, 64, 1000, 1 << 30} {
+		delay := policy.backoff(n)
+		if delay <= 0 || delay > maxRetryDelay {
+			t.Errorf("backoff(%d) = %s, want (0, %s]", n, delay, maxRetryDelay)
+		}
+	}
+}
+
+func TestExponentialBackoffJitterRespectsMaxDelay(t *testing.T) {
+	policy := &ExponentialBackoffRetryPolicy{
+		BaseDelay:  time.Second,
+		MaxDelay:   5 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+
+	for n := 1; n <= 10; n++ {
+		for i := 0; i < 50; i++ {
+			if delay := policy.backoff(n); delay > policy.MaxDelay {
+				t.Fatalf("backoff(%d) = %s, want <= %s", n, delay, policy.MaxDelay)
+			}
+		}
+	}
+}
+
+func TestIsNotSentError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"连接超时", &NotSentError{Cause: &TimeoutError{Phase: TIMEOUT_PHASE_CONNECT}}, true},
+		{"包装的连接错误", fmt.Errorf("wrap: %w", &NotSentError{Cause: errors.New("connection refused")}), true},
+		{"读取超时", &TimeoutError{Phase: TIMEOUT_PHASE_READ_BODY}, false},
+		{"连接中断", io.ErrUnexpectedEOF, false},
+		{"HTTP 503", &HTTPError{StatusCode: http.StatusServiceUnavailable}, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNotSentError(tt.err); got != tt.want {
+			t.Errorf("%s: IsNotSentError(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestExponentialBackoffJitter(t *testing.T) {
+	policy := &ExponentialBackoffRetryPolicy{
+		BaseDelay:  time.Second,
+		MaxDelay:   time.Minute,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(1)
+		if delay < 800*time.Millisecond || delay > 1200*time.Millisecond {
+			t.Fatalf("backoff(1) = %s, want 800ms~1.2s", delay)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.value != "" {
+			header.Set("Retry-After", tt.value)
+		}
+		got, ok := parseRetryAfter(header)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, want %s, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryInterceptorStopsAtMaxRetries(t *testing.T) {
+	var calls int32
+	handler := func(ctx context.Context, call *Call) (*CallResult, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, &HTTPError{StatusCode: http.StatusServiceUnavailable}
+	}
+
+	policy := &ExponentialBackoffRetryPolicy{BaseDelay: time.Millisecond, Multiplier: 1}
+	var attempts []RetryAttempt
+	h := RetryInterceptor(policy, 2, func(a RetryAttempt) {
+		attempts = append(attempts, a)
+	})(handler)
+
+	_, err := h(context.Background(), &Call{Header: http.Header{}, Idempotent: true})
+	if err == nil {
+		t.Fatal("期望返回错误")
+	}
+	if calls != 3 {
+		t.Errorf("调用次数 = %d, want 3", calls)
+	}
+	if len(attempts) != 3 || attempts[2].WillRetry || !attempts[0].WillRetry {
+		t.Errorf("回调记录不正确: %+v", attempts)
+	}
+}
+
+func TestZeroValueClientHonoursRetryCount(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"0000","message":"成功","data":{}}`))
+	}))
+	defer srv.Close()
+
+	client := &DefaultIoTGatewayClient{ServerURL: srv.URL, AppID: "app", AppSecret: "secret", RetryCount: 1}
+	req := newTestRequest()
+	req.idempotent = true
+	body, err := client.doPost(context.Background(), req)
+	if err != nil {
+		t.Fatalf("doPost() error = %v", err)
+	}
+	if calls != 2 || body == "" {
+		t.Errorf("调用次数 = %d, body = %q, want 2次并成功", calls, body)
+	}
+}
+
+// slowServer 每次请求都超过读取超时才响应，并统计收到的请求数
+func slowServer(calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(`{"status":"0000","message":"成功","data":{}}`))
+	}))
+}
+
+func TestReadTimeoutRetryDependsOnIdempotency(t *testing.T) {
+	tests := []struct {
+		name       string
+		idempotent bool
+		wantCalls  int32
+	}{
+		{"非幂等请求只发送一次", false, 1},
+		{"幂等请求按次数重试", true, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := slowServer(&calls)
+			defer srv.Close()
+
+			client := NewIoTGatewayClient(srv.URL, "app", "secret", "")
+			client.SetReadTimeout(50)
+			client.SetRetryCount(2)
+			client.SetRetryPolicy(&ExponentialBackoffRetryPolicy{BaseDelay: time.Millisecond, Multiplier: 1})
+
+			req := newTestRequest()
+			req.idempotent = tt.idempotent
+			_, err := client.Execute(req)
+
+			var timeoutErr *TimeoutError
+			if !errors.As(err, &timeoutErr) || timeoutErr.Phase != TIMEOUT_PHASE_FIRST_BYTE {
+				t.Fatalf("Execute() error = %v, want first_byte 超时", err)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("网关收到 %d 次请求, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestNotSentErrorIsRetriedForMutatingRequest(t *testing.T) {
+	// 关闭服务器后端口拒绝连接，请求不可能发出
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	var attempts []RetryAttempt
+	client := NewIoTGatewayClient(url, "app", "secret", "")
+	client.SetRetryCount(2)
+	client.SetRetryPolicy(&ExponentialBackoffRetryPolicy{BaseDelay: time.Millisecond, Multiplier: 1})
+	client.SetRetryCallback(func(a RetryAttempt) {
+		attempts = append(attempts, a)
+	})
+
+	_, err := client.Execute(newTestRequest())
+	var notSent *NotSentError
+	if !errors.As(err, &notSent) {
+		t.Fatalf("Execute() error = %v, want NotSentError", err)
+	}
+	if len(attempts) != 3 {
+		t.Errorf("尝试次数 = %d, want 3", len(attempts))
+	}
+}
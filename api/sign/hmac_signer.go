@@ -0,0 +1,72 @@
+package sign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/tjfoc/gmsm/sm3"
+	"github.com/zhoudm1743/unicom-gw/api/internal/charset"
+)
+
+// HMACSigner HMAC-SHA256签名：以密钥为key对 CanonicalString(params) 计算HMAC，结果十六进制小写
+//
+// Charset 为参数与密钥使用的字符集，为空时使用UTF-8。
+type HMACSigner struct {
+	Charset string
+}
+
+// Method 签名方法名称
+func (HMACSigner) Method() string {
+	return MethodHMAC
+}
+
+// Sign 计算HMAC-SHA256签名
+func (s HMACSigner) Sign(params map[string]interface{}, secret string) (string, error) {
+	return hmacSign(sha256.New, params, secret, s.Charset)
+}
+
+// Verify 校验HMAC-SHA256签名
+func (s HMACSigner) Verify(params map[string]interface{}, secret, signature string) error {
+	return verify(s, params, secret, strings.ToLower(signature))
+}
+
+// HMACSM3Signer HMAC-SM3签名：以密钥为key对 CanonicalString(params) 计算HMAC，结果十六进制小写
+//
+// Charset 为参数与密钥使用的字符集，为空时使用UTF-8。
+type HMACSM3Signer struct {
+	Charset string
+}
+
+// Method 签名方法名称
+func (HMACSM3Signer) Method() string {
+	return MethodHMACSM3
+}
+
+// Sign 计算HMAC-SM3签名
+func (s HMACSM3Signer) Sign(params map[string]interface{}, secret string) (string, error) {
+	return hmacSign(sm3.New, params, secret, s.Charset)
+}
+
+// Verify 校验HMAC-SM3签名
+func (s HMACSM3Signer) Verify(params map[string]interface{}, secret, signature string) error {
+	return verify(s, params, secret, strings.ToLower(signature))
+}
+
+// hmacSign 使用指定摘要算法计算HMAC，参数与密钥按 cs 编码
+func hmacSign(h func() hash.Hash, params map[string]interface{}, secret, cs string) (string, error) {
+	key, err := charset.Encode(secret, cs)
+	if err != nil {
+		return "", err
+	}
+	data, err := charset.Encode(CanonicalString(params), cs)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(h, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,35 @@
+package sign
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+
+	"github.com/zhoudm1743/unicom-gw/api/internal/charset"
+)
+
+// MD5Signer MD5签名：对 CanonicalString(params)+密钥 计算MD5，结果Base64编码
+//
+// Charset 为摘要输入使用的字符集，为空时使用UTF-8。
+type MD5Signer struct {
+	Charset string
+}
+
+// Method 签名方法名称
+func (MD5Signer) Method() string {
+	return MethodMD5
+}
+
+// Sign 计算MD5签名
+func (s MD5Signer) Sign(params map[string]interface{}, secret string) (string, error) {
+	data, err := charset.Encode(CanonicalString(params)+secret, s.Charset)
+	if err != nil {
+		return "", err
+	}
+	sum := md5.Sum(data)
+	return base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+// Verify 校验MD5签名
+func (s MD5Signer) Verify(params map[string]interface{}, secret, signature string) error {
+	return verify(s, params, secret, signature)
+}
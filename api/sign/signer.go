@@ -0,0 +1,166 @@
+// Package sign 网关请求签名与校验
+//
+// 默认的SM3令牌签名写入 token；MD5、HMAC、HMAC-SM3 写入 sign 与 sign_method，不写 token。
+// 客户端在注入业务数据 data 之前签名，签名只覆盖 app_id、timestamp、trans_id 等顶层字符串参数，
+// data 中的业务参数不在签名范围内。
+package sign
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 签名方法
+const (
+	MethodSM3     = "sm3"      // 网关SM3令牌
+	MethodMD5     = "md5"      // MD5摘要，Base64编码
+	MethodHMAC    = "hmac"     // HMAC-SHA256，十六进制编码
+	MethodHMACSM3 = "hmac-sm3" // HMAC-SM3，十六进制编码
+)
+
+// 签名相关参数名
+const (
+	KeyAppID      = "app_id"
+	KeyTimestamp  = "timestamp"
+	KeyTransID    = "trans_id"
+	KeyToken      = "token"
+	KeySign       = "sign"
+	KeySignMethod = "sign_method"
+	KeyAppSecret  = "app_secrect"
+)
+
+// ErrSignatureMismatch 签名校验不通过
+var ErrSignatureMismatch = errors.New("签名不匹配")
+
+// Signer 签名器，对请求参数计算签名并可校验签名
+type Signer interface {
+	// Method 签名方法名称
+	Method() string
+
+	// Sign 使用密钥对参数签名
+	Sign(params map[string]interface{}, secret string) (string, error)
+
+	// Verify 校验签名，不匹配时返回 ErrSignatureMismatch
+	Verify(params map[string]interface{}, secret, signature string) error
+}
+
+// New 按签名方法名称创建签名器
+func New(method string) (Signer, error) {
+	switch strings.ToLower(method) {
+	case MethodSM3:
+		return SM3TokenSigner{}, nil
+	case MethodMD5:
+		return MD5Signer{}, nil
+	case MethodHMAC:
+		return HMACSigner{}, nil
+	case MethodHMACSM3:
+		return HMACSM3Signer{}, nil
+	}
+	return nil, fmt.Errorf("不支持的签名方法: %s", method)
+}
+
+// WithCharset 返回使用指定字符集计算摘要的签名器，自定义签名器原样返回
+func WithCharset(s Signer, charset string) Signer {
+	switch signer := s.(type) {
+	case SM3TokenSigner:
+		signer.Charset = charset
+		return signer
+	case MD5Signer:
+		signer.Charset = charset
+		return signer
+	case HMACSigner:
+		signer.Charset = charset
+		return signer
+	case HMACSM3Signer:
+		signer.Charset = charset
+		return signer
+	}
+	return s
+}
+
+// Default 获取网关默认使用的SM3令牌签名器
+func Default() Signer {
+	return SM3TokenSigner{}
+}
+
+// Apply 计算签名并写入参数：SM3令牌写入 token，其他方法写入 sign 与 sign_method（不写 token）
+//
+// 只有字符串参数参与签名，调用时 params 中的嵌套业务数据不会被覆盖。
+func Apply(s Signer, params map[string]interface{}, secret string) error {
+	signature, err := s.Sign(params, secret)
+	if err != nil {
+		return err
+	}
+
+	if s.Method() == MethodSM3 {
+		params[KeyToken] = signature
+		return nil
+	}
+	params[KeySign] = signature
+	params[KeySignMethod] = s.Method()
+	return nil
+}
+
+// VerifyParams 从 Apply 写入的位置取出签名并校验，token、sign、sign_method 不参与计算
+func VerifyParams(s Signer, params map[string]interface{}, secret string) error {
+	key := KeySign
+	if s.Method() == MethodSM3 {
+		key = KeyToken
+	}
+
+	signature, _ := params[key].(string)
+	if signature == "" {
+		return fmt.Errorf("缺少签名参数: %s", key)
+	}
+
+	unsigned := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		if k != KeyToken && k != KeySign && k != KeySignMethod {
+			unsigned[k] = v
+		}
+	}
+	return s.Verify(unsigned, secret, signature)
+}
+
+// verify 重新计算签名并以常量时间比较
+func verify(s Signer, params map[string]interface{}, secret, signature string) error {
+	expected, err := s.Sign(params, secret)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) != 1 {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// CanonicalString 按参数名排序拼接 参数名+参数值，只包含非空字符串参数，字符串切片取第一个元素，密钥参数不参与拼接
+func CanonicalString(params map[string]interface{}) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		v := params[k]
+		if v == nil || k == KeyAppSecret {
+			continue
+		}
+
+		// 处理字符串数组
+		if strArray, ok := v.([]string); ok && len(strArray) > 0 {
+			v = strArray[0]
+		}
+
+		if str, ok := v.(string); ok && str != "" {
+			sb.WriteString(k)
+			sb.WriteString(str)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,132 @@
+package sign
+
+import (
+	"errors"
+	"testing"
+)
+
+// testParams 签名向量使用的请求参数，data 为嵌套业务数据，不参与签名
+func testParams() map[string]interface{} {
+	return map[string]interface{}{
+		KeyAppID:     "app123",
+		KeyTimestamp: "2024-01-02 03:04:05 678",
+		KeyTransID:   "20240102030405678123456",
+		"data":       map[string]interface{}{"iccid": "89860000000000000000"},
+	}
+}
+
+// 期望值由独立实现计算
+func TestSignKnownAnswers(t *testing.T) {
+	tests := []struct {
+		name   string
+		signer Signer
+		secret string
+		want   string
+	}{
+		{"SM3令牌", SM3TokenSigner{}, "secret", "a27559d4628296f41ddec2185e4c2fda75fb2bae6005969de7c62beb03b1bb8a"},
+		{"SM3令牌GBK密钥", SM3TokenSigner{Charset: "GBK"}, "测试", "e37e3511bc67ee52539acb7f986026f8dfeef74a45dee193b828d8f47ed1998b"},
+		{"MD5", MD5Signer{}, "secret", "qffV5MlQddOnW7iBjEws9A=="},
+		{"HMAC-SHA256", HMACSigner{}, "secret", "8a14d219325c3e0107bce35de18a78842d947bfa0808736aad0e0af34d6f8e11"},
+		{"HMAC-SM3", HMACSM3Signer{}, "secret", "29c8125d1314c7065b61a4cd6002d20c692333703fdd9f680dfb7cdd18ee9fb0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.signer.Sign(testParams(), tt.secret)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSM3TokenMatchesSigner(t *testing.T) {
+	got, err := SM3Token("app123", "2024-01-02 03:04:05 678", "20240102030405678123456", "secret")
+	if err != nil {
+		t.Fatalf("SM3Token() error = %v", err)
+	}
+	if want := "a27559d4628296f41ddec2185e4c2fda75fb2bae6005969de7c62beb03b1bb8a"; got != want {
+		t.Errorf("SM3Token() = %s, want %s", got, want)
+	}
+
+	if _, err := SM3Token("app123", "", "20240102030405678123456", "secret"); err == nil {
+		t.Error("缺少 timestamp 时应返回错误")
+	}
+}
+
+func TestApplyWritesSignatureFields(t *testing.T) {
+	tests := []struct {
+		method   string
+		wantKeys []string
+		noKeys   []string
+	}{
+		{MethodSM3, []string{KeyToken}, []string{KeySign, KeySignMethod}},
+		{MethodMD5, []string{KeySign, KeySignMethod}, []string{KeyToken}},
+		{MethodHMAC, []string{KeySign, KeySignMethod}, []string{KeyToken}},
+		{MethodHMACSM3, []string{KeySign, KeySignMethod}, []string{KeyToken}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			s, err := New(tt.method)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			params := testParams()
+			if err := Apply(s, params, "secret"); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			for _, k := range tt.wantKeys {
+				if v, _ := params[k].(string); v == "" {
+					t.Errorf("缺少参数 %s", k)
+				}
+			}
+			for _, k := range tt.noKeys {
+				if _, ok := params[k]; ok {
+					t.Errorf("不应写入参数 %s", k)
+				}
+			}
+			if tt.method != MethodSM3 && params[KeySignMethod] != tt.method {
+				t.Errorf("sign_method = %v, want %s", params[KeySignMethod], tt.method)
+			}
+
+			if err := VerifyParams(s, params, "secret"); err != nil {
+				t.Errorf("VerifyParams() error = %v", err)
+			}
+			if err := VerifyParams(s, params, "other"); !errors.Is(err, ErrSignatureMismatch) {
+				t.Errorf("错误密钥 VerifyParams() error = %v, want ErrSignatureMismatch", err)
+			}
+		})
+	}
+}
+
+func TestSignatureDoesNotCoverData(t *testing.T) {
+	for _, method := range []string{MethodSM3, MethodMD5, MethodHMAC, MethodHMACSM3} {
+		s, _ := New(method)
+		params := testParams()
+		if err := Apply(s, params, "secret"); err != nil {
+			t.Fatalf("%s Apply() error = %v", method, err)
+		}
+
+		params["data"] = map[string]interface{}{"iccid": "89860000000000000001"}
+		if err := VerifyParams(s, params, "secret"); err != nil {
+			t.Errorf("%s 修改 data 后 VerifyParams() error = %v, data 不在签名范围内", method, err)
+		}
+	}
+}
+
+func TestCanonicalString(t *testing.T) {
+	params := map[string]interface{}{
+		"b":          "2",
+		"a":          []string{"1", "x"},
+		"empty":      "",
+		"nested":     map[string]interface{}{"k": "v"},
+		KeyAppSecret: "secret",
+	}
+	if got, want := CanonicalString(params), "a1b2"; got != want {
+		t.Errorf("CanonicalString() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+package sign
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tjfoc/gmsm/sm3"
+	"github.com/zhoudm1743/unicom-gw/api/internal/charset"
+)
+
+// SM3TokenSigner 网关SM3令牌签名
+//
+// 令牌为 SM3("app_id"+app_id+"timestamp"+timestamp+"trans_id"+trans_id+密钥) 的十六进制小写字符串，
+// 其他参数不参与计算。Charset 为摘要输入使用的字符集，为空时使用UTF-8。
+type SM3TokenSigner struct {
+	Charset string
+}
+
+// Method 签名方法名称
+func (SM3TokenSigner) Method() string {
+	return MethodSM3
+}
+
+// Sign 计算SM3令牌，app_id、timestamp、trans_id 缺一不可
+func (s SM3TokenSigner) Sign(params map[string]interface{}, secret string) (string, error) {
+	var sb strings.Builder
+	for _, key := range []string{KeyAppID, KeyTimestamp, KeyTransID} {
+		value, ok := params[key].(string)
+		if !ok || value == "" {
+			return "", fmt.Errorf("SM3令牌缺少参数: %s", key)
+		}
+		sb.WriteString(key)
+		sb.WriteString(value)
+	}
+	sb.WriteString(secret)
+
+	data, err := charset.Encode(sb.String(), s.Charset)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sm3.Sm3Sum(data)), nil
+}
+
+// Verify 校验SM3令牌
+func (s SM3TokenSigner) Verify(params map[string]interface{}, secret, signature string) error {
+	return verify(s, params, secret, strings.ToLower(signature))
+}
+
+// SM3Token 使用给定的应用ID、时间戳与交易ID计算网关SM3令牌，摘要输入为UTF-8
+func SM3Token(appID, timestamp, transID, secret string) (string, error) {
+	return SM3TokenSigner{}.Sign(map[string]interface{}{
+		KeyAppID:     appID,
+		KeyTimestamp: timestamp,
+		KeyTransID:   transID,
+	}, secret)
+}
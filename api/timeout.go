@@ -0,0 +1,18 @@
+package api
+
+import "github.com/zhoudm1743/unicom-gw/api/internal/utils"
+
+// TimeoutError 超时错误，Phase 表示超时发生的阶段
+type TimeoutError = utils.TimeoutError
+
+// NotSentError 请求在写出前失败，网关不可能收到该请求，可通过 errors.As 判断
+type NotSentError = utils.NotSentError
+
+const (
+	// 超时阶段
+	TIMEOUT_PHASE_CONNECT       = utils.PhaseConnect
+	TIMEOUT_PHASE_TLS_HANDSHAKE = utils.PhaseTLSHandshake
+	TIMEOUT_PHASE_FIRST_BYTE    = utils.PhaseFirstByte
+	TIMEOUT_PHASE_READ_BODY     = utils.PhaseReadBody
+	TIMEOUT_PHASE_ATTEMPT       = utils.PhaseAttempt
+)
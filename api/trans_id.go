@@ -0,0 +1,38 @@
+package api
+
+import (
+	"time"
+
+	"github.com/zhoudm1743/unicom-gw/api/internal/utils"
+)
+
+// TRANS_ID_LENGTH 默认交易ID长度：17位东八区毫秒时间戳 + 6位序号
+const TRANS_ID_LENGTH = utils.TransIDLength
+
+// TransIDGenerator 交易ID生成器，请求未通过 SetTransId 指定交易ID时由客户端调用
+type TransIDGenerator interface {
+	// NextTransID 生成下一个交易ID，实现需保证并发安全
+	NextTransID() string
+}
+
+// ClockedTransIDGenerator 可选接口，生成器实现后客户端以自身时钟（叠加已学习的时钟偏差）的当前时间生成交易ID
+type ClockedTransIDGenerator interface {
+	// NextTransIDAt 以 now 为时间戳生成下一个交易ID
+	NextTransIDAt(now time.Time) string
+}
+
+// TransIDGeneratorFunc 函数形式的交易ID生成器
+type TransIDGeneratorFunc func() string
+
+// NextTransID 生成下一个交易ID
+func (f TransIDGeneratorFunc) NextTransID() string {
+	return f()
+}
+
+// NewDefaultTransIDGenerator 创建默认交易ID生成器
+//
+// 生成 yyyyMMddHHmmssSSS + 6位零填充序号 的23位定长ID，序号以加密随机数为起点单调递增。
+// 返回的生成器实现 ClockedTransIDGenerator，时间戳取自客户端的 Clock。
+func NewDefaultTransIDGenerator() TransIDGenerator {
+	return utils.NewTransIDSequence()
+}
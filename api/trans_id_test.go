@@ -0,0 +1,85 @@
+package api
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+)
+
+var transIDPattern = regexp.MustCompile(`^[0-9]{23}$`)
+
+func TestDefaultTransIDGeneratorIsUniqueAndFixedWidth(t *testing.T) {
+	generator := NewDefaultTransIDGenerator()
+
+	const workers, perWorker = 8, 5000
+	var mu sync.Mutex
+	seen := make(map[string]bool, workers*perWorker)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids := make([]string, perWorker)
+			for i := range ids {
+				ids[i] = generator.NextTransID()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, id := range ids {
+				if !transIDPattern.MatchString(id) {
+					t.Errorf("交易ID格式不正确: %q", id)
+				}
+				if seen[id] {
+					t.Errorf("交易ID重复: %s", id)
+				}
+				seen[id] = true
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTransIDUsesClientClock(t *testing.T) {
+	fixed := time.Date(2024, 1, 1, 12, 0, 0, int(123*time.Millisecond), GatewayLocation())
+
+	tests := []struct {
+		name       string
+		skew       time.Duration
+		wantPrefix string
+	}{
+		{"固定时钟", 0, "20240101120000123"},
+		{"叠加时钟偏差", time.Hour, "20240101130000123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewIoTGatewayClient("http://127.0.0.1", "app", "secret", "open")
+			client.SetClock(ClockFunc(func() time.Time { return fixed }))
+			if tt.skew != 0 {
+				client.SetClockSkewCompensation(true)
+				client.SetClockSkew(tt.skew)
+			}
+
+			id := client.nextTransID()
+			if len(id) != TRANS_ID_LENGTH || id[:17] != tt.wantPrefix {
+				t.Errorf("nextTransID() = %s, want prefix %s", id, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestCustomTransIDGenerator(t *testing.T) {
+	client := NewIoTGatewayClient("http://127.0.0.1", "app", "secret", "open")
+	client.SetTransIDGenerator(TransIDGeneratorFunc(func() string { return "custom-id" }))
+	if id := client.nextTransID(); id != "custom-id" {
+		t.Errorf("nextTransID() = %s, want custom-id", id)
+	}
+
+	client.SetTransIDGenerator(nil)
+	if id := client.nextTransID(); !transIDPattern.MatchString(id) {
+		t.Errorf("恢复默认生成器后 nextTransID() = %s", id)
+	}
+}
@@ -16,7 +16,7 @@ func main() {
 	// 设置超时时间（可选）
 	client.SetConnectTimeout(2000) // 连接超时时长设置（单位：毫秒），可以不设置，默认两秒
 	// client.SetReadTimeout(30000) // 读取超时时长设置（单位：毫秒），可以不设置，默认三十秒
-	// client.SetRetryCount(1) // 连接超时后，重试次数 0：不重试 1：重试一次 以此类推。可以不设置，默认不重试
+	// client.SetRetryCount(1) // 连接错误、超时或5xx后的重试次数 0：不重试 1：重试一次 以此类推。可以不设置，默认不重试
 
 	// 创建请求
 	req := request.NewCommonJsonRequest()
@@ -25,12 +25,9 @@ func main() {
 	req.SetApiName("wsGetTerminalDetails/V1/1Main")
 	req.SetApiVer("V1.1")
 
-	// 业务参数，需要发给能力提供者
+	// 业务参数，需要发给能力提供者；openId、messageId、version 由客户端自动填充
 	params := map[string]interface{}{
-		"messageId": "1",
-		"version":   "V1.1",
-		"openId":    "46027ouaga*****",
-		"iccids":    []string{"89860625680009634556"},
+		"iccids": []string{"89860625680009634556"},
 	}
 	req.SetParams(params)
 
@@ -41,7 +38,7 @@ func main() {
 	}
 
 	// 输出请求信息
-	fmt.Printf("发送请求流水号: %s\n", req.GetTransId())
+	fmt.Printf("发送请求流水号: %s\n", req.GetLastTransId())
 	fmt.Printf("请求报文: %s\n", req.GetReqText())
 	fmt.Printf("请求是否被成功处理: %v\n", resp.IsSuccess())
 
@@ -52,7 +49,7 @@ func main() {
 		if resp.IsSuccess() {
 			fmt.Printf("成功返回业务参数: %v\n", data)
 		} else {
-			fmt.Printf("处理失败返回消息: %v\n", data)
+			fmt.Printf("处理失败返回消息: %s %s\n", resp.GetStatus(), resp.GetMessage())
 		}
 	}
 }
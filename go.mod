@@ -2,4 +2,7 @@ module github.com/zhoudm1743/unicom-gw
 
 go 1.16
 
-require github.com/tjfoc/gmsm v1.4.1
+require (
+	github.com/tjfoc/gmsm v1.4.1
+	golang.org/x/text v0.13.0
+)